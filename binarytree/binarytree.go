@@ -1,3 +1,13 @@
+// Package binarytree provides an unbalanced binary search tree data structure.
+// Elements are col.PV values ordered by priority; equal priorities are kept
+// to the right of one another.
+// A binary search tree provides three main operations:
+//
+//  1. Insert which adds an element to the tree.
+//  2. Find which returns the element with a given priority.
+//  3. Remove which removes the element with a given priority.
+//
+// This binary search tree data structure is NOT thread-safe.
 package binarytree
 
 import (
@@ -5,129 +15,385 @@ import (
 	"github.com/sgago/col/err"
 )
 
+// An unbalanced binary search tree data structure with type T values.
+type Tree[T any] struct {
+	root  *node[T]
+	count int
+}
+
 type node[T any] struct {
 	col.PV[T]
 	left  *node[T]
 	right *node[T]
 }
 
-func New[T any](pvs ...col.PV[T]) *node[T] {
-	if len(pvs) > 0 {
-		node := node[T]{PV: pvs[0]}
+// New allocates and initializes a new tree with type T values.
+//
+// Values are inserted by index in ascending (non-decreasing) order.
+// In other words, the first value, pvs[0], will be the root of the tree.
+func New[T any](pvs ...col.PV[T]) *Tree[T] {
+	t := Tree[T]{}
+
+	for _, pv := range pvs {
+		t.Insert(pv)
+	}
+
+	return &t
+}
 
-		for _, pv := range pvs[1:] {
-			node.Insert(pv)
+// FromSorted allocates and initializes a new, balanced tree
+// from values sorted by ascending (non-decreasing) priority in O(n) time.
+//
+// Runs of equal priorities are kept to the right of one another,
+// so the tree is only perfectly balanced when priorities are distinct.
+//
+// This function panics if the values are not sorted.
+func FromSorted[T any](pvs []col.PV[T]) *Tree[T] {
+	for i := 1; i < len(pvs); i++ {
+		if pvs[i-1].Priority > pvs[i].Priority {
+			panic("The values are not sorted.")
 		}
+	}
+
+	return &Tree[T]{root: build(pvs), count: len(pvs)}
+}
+
+// Insert adds an element to the tree.
+func (t *Tree[T]) Insert(pv col.PV[T]) {
+	if t.root == nil {
+		t.root = &node[T]{PV: pv}
+	} else {
+		t.root.insert(pv)
+	}
+
+	t.count++
+}
+
+// Find returns the element with the given priority.
+// If no such element exists, Find returns an error.
+func (t *Tree[T]) Find(priority int) (col.PV[T], error) {
+	n := t.root.find(priority)
+
+	if n == nil {
+		return col.PV[T]{}, &err.KeyNotFound{Key: priority}
+	}
+
+	return n.PV, nil
+}
+
+// Remove removes an element with the given priority from the tree.
+// If no such element exists, Remove returns an error.
+func (t *Tree[T]) Remove(priority int) error {
+	root, removed := remove(t.root, priority)
+
+	if !removed {
+		return &err.KeyNotFound{Key: priority}
+	}
+
+	t.root = root
+	t.count--
+
+	return nil
+}
+
+// Min returns the element with the smallest priority in the tree.
+//
+// This method panics if the tree is empty.
+func (t *Tree[T]) Min() col.PV[T] {
+	if t.root == nil {
+		panic("The tree is empty.")
+	}
+
+	n := t.root
+
+	for n.left != nil {
+		n = n.left
+	}
 
-		return &node
+	return n.PV
+}
+
+// Max returns the element with the largest priority in the tree.
+//
+// This method panics if the tree is empty.
+func (t *Tree[T]) Max() col.PV[T] {
+	if t.root == nil {
+		panic("The tree is empty.")
+	}
+
+	n := t.root
+
+	for n.right != nil {
+		n = n.right
 	}
 
-	return &node[T]{}
+	return n.PV
+}
+
+// Floor returns the element with the largest priority less than or equal to priority.
+// If no such element exists, Floor returns an error.
+func (t *Tree[T]) Floor(priority int) (col.PV[T], error) {
+	return t.root.below(priority, true)
+}
+
+// Ceiling returns the element with the smallest priority greater than or equal to priority.
+// If no such element exists, Ceiling returns an error.
+func (t *Tree[T]) Ceiling(priority int) (col.PV[T], error) {
+	return t.root.above(priority, true)
+}
+
+// Predecessor returns the element with the largest priority strictly less than priority.
+// If no such element exists, Predecessor returns an error.
+func (t *Tree[T]) Predecessor(priority int) (col.PV[T], error) {
+	return t.root.below(priority, false)
+}
+
+// Successor returns the element with the smallest priority strictly greater than priority.
+// If no such element exists, Successor returns an error.
+func (t *Tree[T]) Successor(priority int) (col.PV[T], error) {
+	return t.root.above(priority, false)
+}
+
+// Range calls visit for each element with a priority in [lo, hi), in ascending order.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) Range(lo int, hi int, visit func(pv col.PV[T]) bool) {
+	t.root.visitRange(lo, hi, visit)
+}
+
+// PreOrder calls visit for each element, visiting each element before its subtrees.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) PreOrder(visit func(pv col.PV[T]) bool) {
+	t.root.preOrder(visit)
+}
+
+// InOrder calls visit for each element in ascending priority order.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) InOrder(visit func(pv col.PV[T]) bool) {
+	t.root.inOrder(visit)
+}
+
+// PostOrder calls visit for each element, visiting each element after its subtrees.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) PostOrder(visit func(pv col.PV[T]) bool) {
+	t.root.postOrder(visit)
+}
+
+// ToSortedSlice returns all elements in ascending priority order.
+func (t *Tree[T]) ToSortedSlice() []col.PV[T] {
+	return t.root.appendInOrder(make([]col.PV[T], 0, t.count))
+}
+
+// Rebalance rebuilds the tree so that it is balanced, in O(n) time.
+func (t *Tree[T]) Rebalance() {
+	t.root = build(t.ToSortedSlice())
+}
+
+// Count returns the number of elements in the tree.
+func (t *Tree[T]) Count() int {
+	return t.count
+}
+
+// Height returns the number of levels in the tree.
+// An empty tree has a height of zero.
+func (t *Tree[T]) Height() int {
+	return t.root.height()
+}
+
+// IsEmpty returns true if the tree has no elements;
+// otherwise, false.
+func (t *Tree[T]) IsEmpty() bool {
+	return t.Count() == 0
 }
 
-func (n *node[T]) Insert(pv col.PV[T]) {
+// Clear removes all elements from the tree.
+func (t *Tree[T]) Clear() {
+	t.root = nil
+	t.count = 0
+}
 
+func (n *node[T]) insert(pv col.PV[T]) {
 	if pv.Priority < n.Priority {
 		if n.left == nil {
 			n.left = &node[T]{PV: pv}
 		} else {
-			n.left.Insert(pv)
+			n.left.insert(pv)
 		}
 	} else {
 		if n.right == nil {
 			n.right = &node[T]{PV: pv}
 		} else {
-			n.right.Insert(pv)
+			n.right.insert(pv)
 		}
 	}
 }
 
-func (n *node[T]) Find(priority int) (node[T], error) {
-	if n.Priority == priority {
-		return *n, nil
+func (n *node[T]) find(priority int) *node[T] {
+	for n != nil && n.Priority != priority {
+		if n.Priority > priority {
+			n = n.left
+		} else {
+			n = n.right
+		}
 	}
 
-	if n.isLeaf() {
-		return node[T]{}, &err.KeyNotFound{Key: priority}
+	return n
+}
+
+func (n *node[T]) height() int {
+	if n == nil {
+		return 0
 	}
 
-	if n.Priority > priority {
-		return n.left.Find(priority)
-	} else {
-		return n.right.Find(priority)
+	left, right := n.left.height(), n.right.height()
+
+	if left > right {
+		return left + 1
 	}
+
+	return right + 1
 }
 
-func (root *node[T]) Remove(priority int) error {
-	if root.Priority == priority {
-		// This is a special case where we're deleting the root node
-		if root.left != nil {
-			root.left.right = root.right
-			*root = *root.left
-		} else if root.right != nil {
-			*root = *root.right
-		} else {
-			root = nil
-		}
+func (n *node[T]) preOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (visit(n.PV) && n.left.preOrder(visit) && n.right.preOrder(visit))
+}
 
-		return nil
-	}
+func (n *node[T]) inOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (n.left.inOrder(visit) && visit(n.PV) && n.right.inOrder(visit))
+}
 
-	return root.remove(priority, root)
+func (n *node[T]) postOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (n.left.postOrder(visit) && n.right.postOrder(visit) && visit(n.PV))
 }
 
-func (n *node[T]) isLeaf() bool {
-	return n.left == nil && n.right == nil
+func (n *node[T]) appendInOrder(pvs []col.PV[T]) []col.PV[T] {
+	if n == nil {
+		return pvs
+	}
+
+	pvs = n.left.appendInOrder(pvs)
+	pvs = append(pvs, n.PV)
+
+	return n.right.appendInOrder(pvs)
 }
 
-func (n *node[T]) remove(priority int, parent *node[T]) error {
-	if n.Priority == priority {
-		n.deleteNode(parent)
+// build returns the root of a balanced subtree of sorted values.
+func build[T any](pvs []col.PV[T]) *node[T] {
+	if len(pvs) == 0 {
 		return nil
 	}
 
-	if n.isLeaf() && n.Priority != priority {
-		return &err.KeyNotFound{Key: priority}
+	mid := len(pvs) / 2
+
+	// Equal priorities belong to the right, so root the run at its first element.
+	for mid > 0 && pvs[mid-1].Priority == pvs[mid].Priority {
+		mid--
 	}
 
+	return &node[T]{
+		PV:    pvs[mid],
+		left:  build(pvs[:mid]),
+		right: build(pvs[mid+1:]),
+	}
+}
+
+// remove removes the first node with priority from the subtree rooted at n
+// and returns the subtree's new root.
+func remove[T any](n *node[T], priority int) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+
 	if n.Priority > priority {
-		return n.left.remove(priority, n)
-	} else {
-		return n.right.remove(priority, n)
+		n.left, removed = remove(n.left, priority)
+		return n, removed
+	}
+
+	if n.Priority < priority {
+		n.right, removed = remove(n.right, priority)
+		return n, removed
+	}
+
+	if n.left == nil {
+		return n.right, true
 	}
+
+	if n.right == nil {
+		return n.left, true
+	}
+
+	// Replace n with its in-order successor, the leftmost node on the right.
+	parent, successor := n, n.right
+
+	for successor.left != nil {
+		parent, successor = successor, successor.left
+	}
+
+	if parent != n {
+		parent.left = successor.right
+		successor.right = n.right
+	}
+
+	successor.left = n.left
+
+	return successor, true
 }
 
-func (n *node[T]) deleteNode(parent *node[T]) {
-	isLeftChild := parent.left == n
+func (n *node[T]) visitRange(lo int, hi int, visit func(pv col.PV[T]) bool) bool {
+	if n == nil {
+		return true
+	}
 
-	if n.left == nil && n.right != nil {
-		if isLeftChild {
-			parent.left = n.right
-		} else {
-			parent.right = n.right
-		}
-	} else if n.left != nil && n.right == nil {
-		if isLeftChild {
-			parent.left = n.left
+	if lo < n.Priority && !n.left.visitRange(lo, hi, visit) {
+		return false
+	}
+
+	if lo <= n.Priority && n.Priority < hi && !visit(n.PV) {
+		return false
+	}
+
+	if n.Priority < hi {
+		return n.right.visitRange(lo, hi, visit)
+	}
+
+	return true
+}
+
+func (n *node[T]) below(priority int, inclusive bool) (col.PV[T], error) {
+	var found *node[T]
+
+	for n != nil {
+		if n.Priority < priority || (inclusive && n.Priority == priority) {
+			found = n
+			n = n.right
 		} else {
-			parent.right = n.left
+			n = n.left
 		}
-	} else {
-		// TODO: these all need tests
-		if isLeftChild {
-			parent.left = n.left
+	}
 
-			if n.left != nil {
-				n.left.right = n.right
-			}
-		} else {
-			parent.right = n.left
+	if found == nil {
+		return col.PV[T]{}, &err.KeyNotFound{Key: priority}
+	}
+
+	return found.PV, nil
+}
+
+func (n *node[T]) above(priority int, inclusive bool) (col.PV[T], error) {
+	var found *node[T]
 
-			if n.left != nil {
-				n.left.right = n.right
-			}
+	for n != nil {
+		if n.Priority > priority || (inclusive && n.Priority == priority) {
+			found = n
+			n = n.left
+		} else {
+			n = n.right
 		}
 	}
 
-	n = nil
+	if found == nil {
+		return col.PV[T]{}, &err.KeyNotFound{Key: priority}
+	}
+
+	return found.PV, nil
 }
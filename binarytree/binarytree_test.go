@@ -17,7 +17,7 @@ func TestInsert_WithPriorityLessThanCurrentNode_CreatesLeftNode(t *testing.T) {
 
 	bt.Insert(pv1)
 
-	assert.Equal(t, pv1.Priority, bt.left.Priority)
+	assert.Equal(t, pv1.Priority, bt.root.left.Priority)
 }
 
 func TestInsert_WithPriorityGreaterThanCurrentNode_CreatesRightNode(t *testing.T) {
@@ -25,7 +25,7 @@ func TestInsert_WithPriorityGreaterThanCurrentNode_CreatesRightNode(t *testing.T
 
 	bt.Insert(pv3)
 
-	assert.Equal(t, pv3.Priority, bt.right.Priority)
+	assert.Equal(t, pv3.Priority, bt.root.right.Priority)
 }
 
 func TestFind_WithPriorityInTree_NodeIsFound(t *testing.T) {
@@ -87,3 +87,350 @@ func TestRemove(t *testing.T) {
 
 	bt.Remove(7)
 }
+
+func newOrderedTestTree() *Tree[int] {
+	bt := New(col.PV[int]{Priority: 50, Val: 50})
+
+	for _, p := range []int{30, 70, 20, 40, 60, 80} {
+		bt.Insert(col.PV[int]{Priority: p, Val: p})
+	}
+
+	return bt
+}
+
+func TestMin_WithElements_IsSmallest(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	assert.Equal(t, 20, bt.Min().Priority)
+}
+
+func TestMax_WithElements_IsLargest(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	assert.Equal(t, 80, bt.Max().Priority)
+}
+
+func TestFloor_WithPriorityBetweenElements_ReturnsLower(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	pv, e := bt.Floor(65)
+
+	assert.Nil(t, e)
+	assert.Equal(t, 60, pv.Priority)
+}
+
+func TestFloor_WithPriorityInTree_ReturnsSame(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	pv, _ := bt.Floor(40)
+
+	assert.Equal(t, 40, pv.Priority)
+}
+
+func TestFloor_WithPriorityBelowMin_ReturnsError(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	_, e := bt.Floor(10)
+
+	assert.NotNil(t, e)
+}
+
+func TestCeiling_WithPriorityBetweenElements_ReturnsHigher(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	pv, e := bt.Ceiling(41)
+
+	assert.Nil(t, e)
+	assert.Equal(t, 50, pv.Priority)
+}
+
+func TestCeiling_WithPriorityAboveMax_ReturnsError(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	_, e := bt.Ceiling(81)
+
+	assert.NotNil(t, e)
+}
+
+func TestPredecessor_WithPriorityInTree_ReturnsStrictlyLower(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	pv, e := bt.Predecessor(50)
+
+	assert.Nil(t, e)
+	assert.Equal(t, 40, pv.Priority)
+}
+
+func TestSuccessor_WithPriorityInTree_ReturnsStrictlyHigher(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	pv, e := bt.Successor(40)
+
+	assert.Nil(t, e)
+	assert.Equal(t, 50, pv.Priority)
+}
+
+func TestSuccessor_WithMaxPriority_ReturnsError(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	_, e := bt.Successor(80)
+
+	assert.NotNil(t, e)
+}
+
+func TestRange_WithBounds_VisitsInOrder(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	visited := make([]int, 0)
+
+	bt.Range(30, 70, func(pv col.PV[int]) bool {
+		visited = append(visited, pv.Priority)
+		return true
+	})
+
+	assert.Equal(t, []int{30, 40, 50, 60}, visited)
+}
+
+func TestRange_WithVisitReturningFalse_Stops(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	visited := make([]int, 0)
+
+	bt.Range(0, 100, func(pv col.PV[int]) bool {
+		visited = append(visited, pv.Priority)
+		return len(visited) < 2
+	})
+
+	assert.Equal(t, []int{20, 30}, visited)
+}
+
+func TestNew_WithNoValues_IsEmpty(t *testing.T) {
+	bt := New[int]()
+
+	assert.True(t, bt.IsEmpty())
+	assert.Zero(t, bt.Count())
+	assert.Zero(t, bt.Height())
+}
+
+func TestFind_WithEmptyTree_ReturnsError(t *testing.T) {
+	bt := New[int]()
+
+	_, e := bt.Find(0)
+
+	assert.NotNil(t, e)
+}
+
+func TestMin_WithEmptyTree_Panics(t *testing.T) {
+	bt := New[int]()
+
+	assert.Panics(t, func() { bt.Min() })
+}
+
+func TestCount_WithInsertsAndRemoves_IsCorrect(t *testing.T) {
+	bt := New(pv2, pv1, pv3)
+
+	bt.Insert(pv4)
+	bt.Remove(1)
+	bt.Remove(7)
+
+	assert.Equal(t, 3, bt.Count())
+}
+
+func TestHeight_WithSortedInserts_IsCount(t *testing.T) {
+	bt := New(pv1, pv2, pv3, pv4)
+
+	assert.Equal(t, 4, bt.Height())
+}
+
+func TestRemove_WithPriorityNotInTree_ReturnsError(t *testing.T) {
+	bt := New(pv2, pv1, pv3)
+
+	e := bt.Remove(7)
+
+	assert.NotNil(t, e)
+}
+
+func TestRemove_WithRoot_KeepsRemainingElements(t *testing.T) {
+	bt := New(pv2, pv1, pv3, pv4)
+
+	e := bt.Remove(2)
+
+	assert.Nil(t, e)
+
+	for _, p := range []int{1, 3, 4} {
+		_, e := bt.Find(p)
+		assert.Nil(t, e)
+	}
+
+	_, e = bt.Find(2)
+	assert.NotNil(t, e)
+}
+
+func TestRemove_WithTwoChildren_KeepsOrder(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	bt.Remove(30)
+	bt.Remove(50)
+
+	visited := make([]int, 0)
+
+	bt.Range(0, 100, func(pv col.PV[int]) bool {
+		visited = append(visited, pv.Priority)
+		return true
+	})
+
+	assert.Equal(t, []int{20, 40, 60, 70, 80}, visited)
+}
+
+func TestRemove_WithLastElement_IsEmpty(t *testing.T) {
+	bt := New(pv1)
+
+	bt.Remove(1)
+
+	assert.True(t, bt.IsEmpty())
+	assert.Zero(t, bt.Height())
+}
+
+func TestClear_WithElements_IsEmpty(t *testing.T) {
+	bt := New(pv2, pv1, pv3)
+
+	bt.Clear()
+
+	assert.True(t, bt.IsEmpty())
+	assert.Zero(t, bt.Height())
+}
+
+func TestASCII_WithElements_RendersSideways(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	expected := "" +
+		"    /-- 80: 80\n" +
+		"/-- 70: 70\n" +
+		"|   \\-- 60: 60\n" +
+		"50: 50\n" +
+		"|   /-- 40: 40\n" +
+		"\\-- 30: 30\n" +
+		"    \\-- 20: 20\n"
+
+	assert.Equal(t, expected, bt.ASCII())
+}
+
+func TestASCII_WithEmptyTree_IsEmpty(t *testing.T) {
+	bt := New[int]()
+
+	assert.Empty(t, bt.ASCII())
+}
+
+func TestDOT_WithElements_RendersDigraph(t *testing.T) {
+	bt := New(pv2, pv1, pv3)
+
+	expected := "digraph {\n" +
+		"\tn0 [label=\"2: 2\"];\n" +
+		"\tn1 [label=\"1: 1\"];\n" +
+		"\tn0 -> n1 [label=\"L\"];\n" +
+		"\tn2 [label=\"3: 3\"];\n" +
+		"\tn0 -> n2 [label=\"R\"];\n" +
+		"}\n"
+
+	assert.Equal(t, expected, bt.DOT())
+}
+
+func TestFromSorted_WithSortedValues_IsBalanced(t *testing.T) {
+	pvs := make([]col.PV[int], 0, 1023)
+
+	for i := 0; i < 1023; i++ {
+		pvs = append(pvs, col.PV[int]{Priority: i, Val: i})
+	}
+
+	bt := FromSorted(pvs)
+
+	assert.Equal(t, 1023, bt.Count())
+	assert.Equal(t, 10, bt.Height())
+	assert.Equal(t, pvs, bt.ToSortedSlice())
+}
+
+func TestFromSorted_WithDuplicates_FindsAllInRange(t *testing.T) {
+	pvs := []col.PV[int]{pv1, pv2, pv2, pv2, pv3}
+
+	bt := FromSorted(pvs)
+
+	count := 0
+
+	bt.Range(2, 3, func(pv col.PV[int]) bool {
+		count++
+		return true
+	})
+
+	assert.Equal(t, 3, count)
+}
+
+func TestFromSorted_WithUnsortedValues_Panics(t *testing.T) {
+	assert.Panics(t, func() { FromSorted([]col.PV[int]{pv2, pv1}) })
+}
+
+func TestFromSorted_WithNoValues_IsEmpty(t *testing.T) {
+	bt := FromSorted[int](nil)
+
+	assert.True(t, bt.IsEmpty())
+}
+
+func TestToSortedSlice_WithElements_IsSorted(t *testing.T) {
+	bt := New(pv3, pv1, pv4, pv2)
+
+	assert.Equal(t, []col.PV[int]{pv1, pv2, pv3, pv4}, bt.ToSortedSlice())
+}
+
+func TestRebalance_WithDegenerateTree_IsBalanced(t *testing.T) {
+	bt := New[int]()
+
+	for i := 0; i < 127; i++ {
+		bt.Insert(col.PV[int]{Priority: i, Val: i})
+	}
+
+	bt.Rebalance()
+
+	assert.Equal(t, 7, bt.Height())
+	assert.Equal(t, 127, bt.Count())
+}
+
+func visitAll(traverse func(visit func(pv col.PV[int]) bool)) []int {
+	visited := make([]int, 0)
+
+	traverse(func(pv col.PV[int]) bool {
+		visited = append(visited, pv.Priority)
+		return true
+	})
+
+	return visited
+}
+
+func TestPreOrder_WithElements_VisitsRootFirst(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	assert.Equal(t, []int{50, 30, 20, 40, 70, 60, 80}, visitAll(bt.PreOrder))
+}
+
+func TestInOrder_WithElements_VisitsAscending(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	assert.Equal(t, []int{20, 30, 40, 50, 60, 70, 80}, visitAll(bt.InOrder))
+}
+
+func TestPostOrder_WithElements_VisitsRootLast(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	assert.Equal(t, []int{20, 40, 30, 60, 80, 70, 50}, visitAll(bt.PostOrder))
+}
+
+func TestInOrder_WithVisitReturningFalse_Stops(t *testing.T) {
+	bt := newOrderedTestTree()
+
+	visited := 0
+
+	bt.InOrder(func(pv col.PV[int]) bool {
+		visited++
+		return pv.Priority < 40
+	})
+
+	assert.Equal(t, 3, visited)
+}
@@ -0,0 +1,144 @@
+package binarytree
+
+import (
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+)
+
+// An immutable, unbalanced binary search tree data structure with type T values.
+//
+// Insert and Remove return a new tree and leave the receiver unchanged.
+// Unchanged subtrees are shared between versions through path copying,
+// so each operation only allocates the nodes along the path it walks.
+// Because no version is ever modified, every version is safe to read
+// from any number of go-routines.
+type Persistent[T any] struct {
+	root  *node[T]
+	count int
+}
+
+// NewPersistent allocates and initializes a new immutable tree with type T values.
+//
+// Values are inserted by index in ascending (non-decreasing) order.
+// In other words, the first value, pvs[0], will be the root of the tree.
+func NewPersistent[T any](pvs ...col.PV[T]) *Persistent[T] {
+	p := &Persistent[T]{}
+
+	for _, pv := range pvs {
+		p = p.Insert(pv)
+	}
+
+	return p
+}
+
+// Insert returns a new tree with pv added.
+func (p *Persistent[T]) Insert(pv col.PV[T]) *Persistent[T] {
+	return &Persistent[T]{
+		root:  insertCopy(p.root, pv),
+		count: p.count + 1,
+	}
+}
+
+// Remove returns a new tree without an element with the given priority.
+// If no such element exists, Remove returns the receiver and an error.
+func (p *Persistent[T]) Remove(priority int) (*Persistent[T], error) {
+	root, removed := removeCopy(p.root, priority)
+
+	if !removed {
+		return p, &err.KeyNotFound{Key: priority}
+	}
+
+	return &Persistent[T]{root: root, count: p.count - 1}, nil
+}
+
+// Find returns the element with the given priority.
+// If no such element exists, Find returns an error.
+func (p *Persistent[T]) Find(priority int) (col.PV[T], error) {
+	n := p.root.find(priority)
+
+	if n == nil {
+		return col.PV[T]{}, &err.KeyNotFound{Key: priority}
+	}
+
+	return n.PV, nil
+}
+
+// Range calls visit for each element with a priority in [lo, hi), in ascending order.
+// Returning false from visit stops the iteration.
+func (p *Persistent[T]) Range(lo int, hi int, visit func(pv col.PV[T]) bool) {
+	p.root.visitRange(lo, hi, visit)
+}
+
+// Count returns the number of elements in the tree.
+func (p *Persistent[T]) Count() int {
+	return p.count
+}
+
+// Height returns the number of levels in the tree.
+// An empty tree has a height of zero.
+func (p *Persistent[T]) Height() int {
+	return p.root.height()
+}
+
+// IsEmpty returns true if the tree has no elements;
+// otherwise, false.
+func (p *Persistent[T]) IsEmpty() bool {
+	return p.Count() == 0
+}
+
+// insertCopy returns a copy of the subtree rooted at n with pv added.
+func insertCopy[T any](n *node[T], pv col.PV[T]) *node[T] {
+	if n == nil {
+		return &node[T]{PV: pv}
+	}
+
+	c := *n
+
+	if pv.Priority < n.Priority {
+		c.left = insertCopy(n.left, pv)
+	} else {
+		c.right = insertCopy(n.right, pv)
+	}
+
+	return &c
+}
+
+// removeCopy returns a copy of the subtree rooted at n
+// without the first node with priority.
+func removeCopy[T any](n *node[T], priority int) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+	c := *n
+
+	switch {
+	case n.Priority > priority:
+		c.left, removed = removeCopy(n.left, priority)
+	case n.Priority < priority:
+		c.right, removed = removeCopy(n.right, priority)
+	case n.left == nil:
+		return n.right, true
+	case n.right == nil:
+		return n.left, true
+	default:
+		// Replace n with a copy of its in-order successor.
+		min := n.right
+
+		for min.left != nil {
+			min = min.left
+		}
+
+		c.PV = min.PV
+		c.right, _ = removeCopy(n.right, min.Priority)
+
+		return &c, true
+	}
+
+	if !removed {
+		return n, false
+	}
+
+	return &c, true
+}
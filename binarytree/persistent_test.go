@@ -0,0 +1,103 @@
+package binarytree
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+func priorities[T any](p *Persistent[T]) []int {
+	result := make([]int, 0)
+
+	p.Range(-1<<31, 1<<31-1, func(pv col.PV[T]) bool {
+		result = append(result, pv.Priority)
+		return true
+	})
+
+	return result
+}
+
+func TestNewPersistent_WithNoValues_IsEmpty(t *testing.T) {
+	p := NewPersistent[int]()
+
+	assert.True(t, p.IsEmpty())
+	assert.Zero(t, p.Height())
+}
+
+func TestInsert_WithPersistent_OldVersionIsUnchanged(t *testing.T) {
+	v1 := NewPersistent(pv2, pv1)
+
+	v2 := v1.Insert(pv3)
+
+	assert.Equal(t, []int{1, 2}, priorities(v1))
+	assert.Equal(t, []int{1, 2, 3}, priorities(v2))
+	assert.Equal(t, 2, v1.Count())
+	assert.Equal(t, 3, v2.Count())
+}
+
+func TestInsert_WithPersistent_SharesUnchangedSubtrees(t *testing.T) {
+	v1 := NewPersistent(pv2, pv1)
+
+	v2 := v1.Insert(pv3)
+
+	assert.NotSame(t, v1.root, v2.root)
+	assert.Same(t, v1.root.left, v2.root.left)
+}
+
+func TestRemove_WithPersistent_OldVersionIsUnchanged(t *testing.T) {
+	v1 := NewPersistent(pv2, pv1, pv4, pv3)
+
+	v2, e := v1.Remove(2)
+
+	assert.Nil(t, e)
+	assert.Equal(t, []int{1, 2, 3, 4}, priorities(v1))
+	assert.Equal(t, []int{1, 3, 4}, priorities(v2))
+	assert.Equal(t, 3, v2.Count())
+}
+
+func TestRemove_WithPersistentMissingPriority_ReturnsSameVersion(t *testing.T) {
+	v1 := NewPersistent(pv2, pv1)
+
+	v2, e := v1.Remove(7)
+
+	assert.NotNil(t, e)
+	assert.Same(t, v1, v2)
+}
+
+func TestFind_WithPersistent_IsCorrect(t *testing.T) {
+	p := NewPersistent(pv2, pv1, pv3)
+
+	pv, e := p.Find(3)
+
+	assert.Nil(t, e)
+	assert.Equal(t, pv3, pv)
+
+	_, e = p.Find(7)
+
+	assert.NotNil(t, e)
+}
+
+func TestPersistent_WithConcurrentReaders_VersionsAreStable(t *testing.T) {
+	p := NewPersistent(pv2, pv1, pv3)
+	versions := []*Persistent[int]{p}
+
+	for i := 10; i < 50; i++ {
+		p = p.Insert(col.PV[int]{Priority: i, Val: i})
+		versions = append(versions, p)
+	}
+
+	var wg sync.WaitGroup
+
+	for i, v := range versions {
+		wg.Add(1)
+
+		go func(i int, v *Persistent[int]) {
+			defer wg.Done()
+			assert.Equal(t, 3+i, len(priorities(v)))
+		}(i, v)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,82 @@
+package binarytree
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ASCII returns the shape of the tree as sideways ASCII art,
+// one element per line, with the right subtree above each element
+// and the left subtree below it. Each element is shown as priority: value.
+//
+// For example, a tree built from priorities 2, 1, and 3 renders as:
+//
+//	/-- 3: 3
+//	2: 2
+//	\-- 1: 1
+func (t *Tree[T]) ASCII() string {
+	var b strings.Builder
+
+	t.root.writeASCII(&b, "", "", "", "")
+
+	return b.String()
+}
+
+// DOT returns the shape of the tree as a Graphviz DOT digraph.
+// Each element is labelled as priority: value, and each edge is
+// labelled L or R for the left or right child, respectively.
+func (t *Tree[T]) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph {\n")
+
+	if t.root != nil {
+		id := 0
+		t.root.writeDOT(&b, &id)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (n *node[T]) label() string {
+	return fmt.Sprintf("%d: %v", n.Priority, n.Val)
+}
+
+// writeASCII writes the subtree rooted at n. The prefix is written before
+// every line, the edge before n's own line, and the above and below
+// strings extend the prefix for the right and left subtrees, respectively.
+func (n *node[T]) writeASCII(b *strings.Builder, prefix string, edge string, above string, below string) {
+	if n == nil {
+		return
+	}
+
+	n.right.writeASCII(b, prefix+above, "/-- ", "    ", "|   ")
+
+	b.WriteString(prefix)
+	b.WriteString(edge)
+	b.WriteString(n.label())
+	b.WriteString("\n")
+
+	n.left.writeASCII(b, prefix+below, "\\-- ", "|   ", "    ")
+}
+
+func (n *node[T]) writeDOT(b *strings.Builder, id *int) int {
+	self := *id
+	*id++
+
+	fmt.Fprintf(b, "\tn%d [label=%q];\n", self, n.label())
+
+	if n.left != nil {
+		child := n.left.writeDOT(b, id)
+		fmt.Fprintf(b, "\tn%d -> n%d [label=\"L\"];\n", self, child)
+	}
+
+	if n.right != nil {
+		child := n.right.writeDOT(b, id)
+		fmt.Fprintf(b, "\tn%d -> n%d [label=\"R\"];\n", self, child)
+	}
+
+	return self
+}
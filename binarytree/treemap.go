@@ -0,0 +1,251 @@
+package binarytree
+
+import (
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+	"github.com/sgago/col/internal/avl"
+	"golang.org/x/exp/constraints"
+)
+
+// An AVL-balanced ordered map data structure with type K keys and type V values.
+//
+// This ordered map data structure is NOT thread-safe.
+type TreeMap[K any, V any] struct {
+	root    *mapnode[K, V]
+	count   int
+	compare func(a K, b K) int
+}
+
+type mapnode[K any, V any] struct {
+	col.KV[K, V]
+	height int
+	left   *mapnode[K, V]
+	right  *mapnode[K, V]
+}
+
+// NewTreeMap allocates and initializes a new ordered map
+// with naturally ordered type K keys and type V values.
+func NewTreeMap[K constraints.Ordered, V any](kvs ...col.KV[K, V]) *TreeMap[K, V] {
+	return NewTreeMapFunc(func(a K, b K) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	}, kvs...)
+}
+
+// NewTreeMapFunc allocates and initializes a new ordered map
+// with type K keys, ordered by compare, and type V values.
+//
+// The compare function returns a negative number if a < b,
+// a positive number if a > b, and zero if a and b are equal.
+func NewTreeMapFunc[K any, V any](compare func(a K, b K) int, kvs ...col.KV[K, V]) *TreeMap[K, V] {
+	if compare == nil {
+		panic("The compare function cannot be nil.")
+	}
+
+	m := TreeMap[K, V]{compare: compare}
+
+	for _, kv := range kvs {
+		m.Put(kv.Key, kv.Val)
+	}
+
+	return &m
+}
+
+// Put associates val with key, replacing any value already stored for key.
+func (m *TreeMap[K, V]) Put(key K, val V) {
+	var added bool
+
+	m.root, added = m.put(m.root, key, val)
+
+	if added {
+		m.count++
+	}
+}
+
+// Get returns the value associated with key.
+// If key is not in the map, Get returns an error.
+func (m *TreeMap[K, V]) Get(key K) (V, error) {
+	n := m.find(key)
+
+	if n == nil {
+		var notFound V
+		return notFound, &err.NotFound{}
+	}
+
+	return n.Val, nil
+}
+
+// Contains returns true if key is in the map;
+// otherwise, false.
+func (m *TreeMap[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Delete removes key and its value from the map.
+// If key is not in the map, Delete returns an error.
+func (m *TreeMap[K, V]) Delete(key K) error {
+	root, removed := m.delete(m.root, key)
+
+	if !removed {
+		return &err.NotFound{}
+	}
+
+	m.root = root
+	m.count--
+
+	return nil
+}
+
+// Each calls visit for each key and value in ascending key order.
+// Returning false from visit stops the iteration.
+func (m *TreeMap[K, V]) Each(visit func(key K, val V) bool) {
+	m.root.each(visit)
+}
+
+// Keys returns all keys in ascending order.
+func (m *TreeMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.count)
+
+	m.Each(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+// Values returns all values in ascending key order.
+func (m *TreeMap[K, V]) Values() []V {
+	vals := make([]V, 0, m.count)
+
+	m.Each(func(_ K, val V) bool {
+		vals = append(vals, val)
+		return true
+	})
+
+	return vals
+}
+
+// Count returns the number of keys in the map.
+func (m *TreeMap[K, V]) Count() int {
+	return m.count
+}
+
+// Height returns the number of levels in the map's tree.
+// An empty map has a height of zero.
+func (m *TreeMap[K, V]) Height() int {
+	return avl.Height(m.root)
+}
+
+// IsEmpty returns true if the map has no keys;
+// otherwise, false.
+func (m *TreeMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// Clear removes all keys and values from the map.
+func (m *TreeMap[K, V]) Clear() {
+	m.root = nil
+	m.count = 0
+}
+
+func (m *TreeMap[K, V]) find(key K) *mapnode[K, V] {
+	n := m.root
+
+	for n != nil {
+		c := m.compare(key, n.Key)
+
+		if c == 0 {
+			return n
+		}
+
+		if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return nil
+}
+
+func (m *TreeMap[K, V]) put(n *mapnode[K, V], key K, val V) (*mapnode[K, V], bool) {
+	if n == nil {
+		return &mapnode[K, V]{KV: col.KV[K, V]{Key: key, Val: val}, height: 1}, true
+	}
+
+	var added bool
+
+	switch c := m.compare(key, n.Key); {
+	case c < 0:
+		n.left, added = m.put(n.left, key, val)
+	case c > 0:
+		n.right, added = m.put(n.right, key, val)
+	default:
+		n.Val = val
+		return n, false
+	}
+
+	return avl.Rebalance(n), added
+}
+
+func (m *TreeMap[K, V]) delete(n *mapnode[K, V], key K) (*mapnode[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+
+	switch c := m.compare(key, n.Key); {
+	case c < 0:
+		n.left, removed = m.delete(n.left, key)
+	case c > 0:
+		n.right, removed = m.delete(n.right, key)
+	default:
+		return avl.RemoveRoot(n), true
+	}
+
+	if !removed {
+		return n, false
+	}
+
+	return avl.Rebalance(n), true
+}
+
+func (n *mapnode[K, V]) each(visit func(key K, val V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.left.each(visit) && visit(n.Key, n.Val) && n.right.each(visit)
+}
+
+func (n *mapnode[K, V]) Left() *mapnode[K, V] {
+	return n.left
+}
+
+func (n *mapnode[K, V]) SetLeft(left *mapnode[K, V]) {
+	n.left = left
+}
+
+func (n *mapnode[K, V]) Right() *mapnode[K, V] {
+	return n.right
+}
+
+func (n *mapnode[K, V]) SetRight(right *mapnode[K, V]) {
+	n.right = right
+}
+
+func (n *mapnode[K, V]) Height() int {
+	return n.height
+}
+
+func (n *mapnode[K, V]) Update() {
+	n.height = avl.Measure(n)
+}
@@ -0,0 +1,146 @@
+package binarytree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTreeMap_WithValues_CountIsCorrect(t *testing.T) {
+	m := NewTreeMap(
+		col.KV[string, int]{Key: "b", Val: 2},
+		col.KV[string, int]{Key: "a", Val: 1},
+		col.KV[string, int]{Key: "b", Val: 3},
+	)
+
+	assert.Equal(t, 2, m.Count())
+}
+
+func TestPut_WithExistingKey_ReplacesValue(t *testing.T) {
+	m := NewTreeMap[string, int]()
+
+	m.Put("a", 1)
+	m.Put("a", 2)
+
+	val, e := m.Get("a")
+
+	assert.Nil(t, e)
+	assert.Equal(t, 2, val)
+	assert.Equal(t, 1, m.Count())
+}
+
+func TestGet_WithMissingKey_ReturnsError(t *testing.T) {
+	m := NewTreeMap[string, int]()
+
+	_, e := m.Get("a")
+
+	assert.NotNil(t, e)
+}
+
+func TestContains_WithKeys_IsCorrect(t *testing.T) {
+	m := NewTreeMap[int, int]()
+
+	m.Put(1, 1)
+
+	assert.True(t, m.Contains(1))
+	assert.False(t, m.Contains(2))
+}
+
+func TestDelete_WithKey_KeyIsRemoved(t *testing.T) {
+	m := NewTreeMap[int, int]()
+
+	m.Put(1, 1)
+	m.Put(2, 2)
+
+	e := m.Delete(1)
+
+	assert.Nil(t, e)
+	assert.False(t, m.Contains(1))
+	assert.Equal(t, 1, m.Count())
+}
+
+func TestDelete_WithMissingKey_ReturnsError(t *testing.T) {
+	m := NewTreeMap[int, int]()
+
+	assert.NotNil(t, m.Delete(1))
+}
+
+func TestKeys_WithUnorderedPuts_AreSorted(t *testing.T) {
+	m := NewTreeMap[string, int]()
+
+	for _, k := range []string{"pear", "apple", "fig", "kiwi"} {
+		m.Put(k, len(k))
+	}
+
+	assert.Equal(t, []string{"apple", "fig", "kiwi", "pear"}, m.Keys())
+	assert.Equal(t, []int{5, 3, 4, 4}, m.Values())
+}
+
+func TestEach_WithVisitReturningFalse_Stops(t *testing.T) {
+	m := NewTreeMap[int, int]()
+
+	for i := 0; i < 10; i++ {
+		m.Put(i, i)
+	}
+
+	visited := 0
+
+	m.Each(func(key int, val int) bool {
+		visited++
+		return key < 2
+	})
+
+	assert.Equal(t, 3, visited)
+}
+
+func TestNewTreeMapFunc_WithCompositeKeys_OrdersByComparator(t *testing.T) {
+	type key struct {
+		tenant    string
+		timestamp int
+	}
+
+	m := NewTreeMapFunc[key, int](func(a key, b key) int {
+		if c := strings.Compare(a.tenant, b.tenant); c != 0 {
+			return c
+		}
+
+		return a.timestamp - b.timestamp
+	})
+
+	m.Put(key{"b", 1}, 0)
+	m.Put(key{"a", 2}, 0)
+	m.Put(key{"a", 1}, 0)
+
+	assert.Equal(t, []key{{"a", 1}, {"a", 2}, {"b", 1}}, m.Keys())
+}
+
+func TestNewTreeMapFunc_WithNilCompare_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewTreeMapFunc[int, int](nil) })
+}
+
+func TestHeight_WithSortedPuts_IsBalanced(t *testing.T) {
+	m := NewTreeMap[int, int]()
+
+	for i := 0; i < 1023; i++ {
+		m.Put(i, i)
+	}
+
+	for i := 0; i < 1023; i += 3 {
+		m.Delete(i)
+	}
+
+	assert.LessOrEqual(t, m.Height(), 14)
+	assert.Equal(t, 682, m.Count())
+}
+
+func TestClear_WithKeys_IsEmpty(t *testing.T) {
+	m := NewTreeMap[int, int]()
+
+	m.Put(1, 1)
+	m.Clear()
+
+	assert.True(t, m.IsEmpty())
+	assert.Empty(t, m.Keys())
+}
@@ -0,0 +1,639 @@
+// Package btree provides a B-tree data structure, an ordered map
+// that keeps many keys per node for cache-friendly storage.
+// With a minimum degree of d, every node except the root holds between
+// d-1 and 2d-1 keys, and all leaves are at the same depth.
+// A B-tree provides three main operations:
+//
+//  1. Insert which adds or replaces a key and its value.
+//  2. Get which returns the value for a key.
+//  3. Delete which removes a key and its value.
+//
+// This B-tree data structure is NOT thread-safe.
+package btree
+
+import (
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+	"golang.org/x/exp/constraints"
+)
+
+// The default minimum degree of a B-tree.
+const DefaultDegree int = 32
+
+// A B-tree data structure with type K keys and type V values.
+type btree[K any, V any] struct {
+	root    *node[K, V]
+	degree  int
+	count   int
+	compare func(a K, b K) int
+}
+
+// A leaf node has no children. An internal node with n items has n+1 children.
+type node[K any, V any] struct {
+	items    []col.KV[K, V]
+	children []*node[K, V]
+}
+
+// New allocates and initializes a new B-tree with naturally ordered
+// type K keys and type V values.
+//
+// The degree is the minimum degree of the tree and must be at least 2.
+// Zero uses DefaultDegree.
+func New[K constraints.Ordered, V any](degree int) *btree[K, V] {
+	return NewFunc[K, V](degree, func(a K, b K) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// NewFunc allocates and initializes a new B-tree with type K keys,
+// ordered by compare, and type V values.
+//
+// The compare function returns a negative number if a < b,
+// a positive number if a > b, and zero if a and b are equal.
+//
+// The degree is the minimum degree of the tree and must be at least 2.
+// Zero uses DefaultDegree.
+func NewFunc[K any, V any](degree int, compare func(a K, b K) int) *btree[K, V] {
+	if degree == 0 {
+		degree = DefaultDegree
+	}
+
+	if degree < 2 {
+		panic("The degree must be at least 2.")
+	}
+
+	if compare == nil {
+		panic("The compare function cannot be nil.")
+	}
+
+	return &btree[K, V]{degree: degree, compare: compare}
+}
+
+// Insert associates val with key, replacing any value already stored for key.
+func (b *btree[K, V]) Insert(key K, val V) {
+	if b.root == nil {
+		b.root = b.newNode(false)
+		b.root.items = append(b.root.items, col.KV[K, V]{Key: key, Val: val})
+		b.count++
+
+		return
+	}
+
+	if len(b.root.items) == b.maxItems() {
+		root := b.newNode(true)
+		root.children = append(root.children, b.root)
+		b.splitChild(root, 0)
+		b.root = root
+	}
+
+	if b.insert(b.root, key, val) {
+		b.count++
+	}
+}
+
+// Get returns the value associated with key.
+// If key is not in the tree, Get returns an error.
+func (b *btree[K, V]) Get(key K) (V, error) {
+	n := b.root
+
+	for n != nil {
+		i, found := b.search(n, key)
+
+		if found {
+			return n.items[i].Val, nil
+		}
+
+		if n.isLeaf() {
+			break
+		}
+
+		n = n.children[i]
+	}
+
+	var notFound V
+
+	return notFound, &err.NotFound{}
+}
+
+// Contains returns true if key is in the tree;
+// otherwise, false.
+func (b *btree[K, V]) Contains(key K) bool {
+	_, e := b.Get(key)
+
+	return e == nil
+}
+
+// Delete removes key and its value from the tree.
+// If key is not in the tree, Delete returns an error.
+func (b *btree[K, V]) Delete(key K) error {
+	if b.root == nil {
+		return &err.NotFound{}
+	}
+
+	// The descent can merge the root's children even when key is missing,
+	// so the root is shrunk either way.
+	removed := b.delete(b.root, key)
+	b.shrink()
+
+	if !removed {
+		return &err.NotFound{}
+	}
+
+	b.count--
+
+	return nil
+}
+
+// DeleteRange removes all keys in [lo, hi) and their values from the tree
+// and returns the number of keys removed.
+// Each key is found and removed with its own descent from the root,
+// so removing k keys takes O(k log n) time and O(1) extra space.
+func (b *btree[K, V]) DeleteRange(lo K, hi K) int {
+	removed := 0
+
+	for {
+		key, ok := b.ceiling(lo)
+
+		if !ok || b.compare(key, hi) >= 0 {
+			return removed
+		}
+
+		b.Delete(key)
+		removed++
+	}
+}
+
+// Load adds keys and values sorted by strictly ascending key.
+// When the tree is empty, the tree is built bottom-up in O(n) time;
+// otherwise, each key and value is inserted.
+//
+// This method panics if the keys are not sorted or are not unique.
+func (b *btree[K, V]) Load(kvs []col.KV[K, V]) {
+	for i := 1; i < len(kvs); i++ {
+		if b.compare(kvs[i-1].Key, kvs[i].Key) >= 0 {
+			panic("The keys are not sorted and unique.")
+		}
+	}
+
+	if !b.IsEmpty() {
+		for _, kv := range kvs {
+			b.Insert(kv.Key, kv.Val)
+		}
+
+		return
+	}
+
+	if len(kvs) > 0 {
+		b.root = b.build(kvs)
+		b.count = len(kvs)
+	}
+}
+
+// Each calls visit for each key and value in ascending key order.
+// Returning false from visit stops the iteration.
+func (b *btree[K, V]) Each(visit func(key K, val V) bool) {
+	b.each(b.root, visit)
+}
+
+// Range calls visit for each key in [lo, hi) and its value, in ascending key order.
+// Returning false from visit stops the iteration.
+func (b *btree[K, V]) Range(lo K, hi K, visit func(key K, val V) bool) {
+	b.visitRange(b.root, lo, hi, visit)
+}
+
+// Count returns the number of keys in the tree.
+func (b *btree[K, V]) Count() int {
+	return b.count
+}
+
+// Degree returns the minimum degree of the tree.
+func (b *btree[K, V]) Degree() int {
+	return b.degree
+}
+
+// Height returns the number of levels in the tree.
+// An empty tree has a height of zero.
+func (b *btree[K, V]) Height() int {
+	height := 0
+
+	for n := b.root; n != nil; height++ {
+		if n.isLeaf() {
+			n = nil
+		} else {
+			n = n.children[0]
+		}
+	}
+
+	return height
+}
+
+// IsEmpty returns true if the tree has no keys;
+// otherwise, false.
+func (b *btree[K, V]) IsEmpty() bool {
+	return b.Count() == 0
+}
+
+// Clear removes all keys and values from the tree.
+func (b *btree[K, V]) Clear() {
+	b.root = nil
+	b.count = 0
+}
+
+// ceiling returns the least key in the tree that is greater than or equal to key
+// and true, or false if there is no such key.
+func (b *btree[K, V]) ceiling(key K) (K, bool) {
+	var least K
+	found := false
+	n := b.root
+
+	for n != nil {
+		i, exact := b.search(n, key)
+
+		if exact {
+			return n.items[i].Key, true
+		}
+
+		if i < len(n.items) {
+			least, found = n.items[i].Key, true
+		}
+
+		if n.isLeaf() {
+			break
+		}
+
+		n = n.children[i]
+	}
+
+	return least, found
+}
+
+// shrink drops the root when it has no items left,
+// making its only child, if any, the new root.
+func (b *btree[K, V]) shrink() {
+	if len(b.root.items) > 0 {
+		return
+	}
+
+	if b.root.isLeaf() {
+		b.root = nil
+	} else {
+		b.root = b.root.children[0]
+	}
+}
+
+func (n *node[K, V]) isLeaf() bool {
+	return n.children == nil
+}
+
+func (b *btree[K, V]) maxItems() int {
+	return 2*b.degree - 1
+}
+
+func (b *btree[K, V]) newNode(internal bool) *node[K, V] {
+	n := node[K, V]{items: make([]col.KV[K, V], 0, b.maxItems())}
+
+	if internal {
+		n.children = make([]*node[K, V], 0, b.maxItems()+1)
+	}
+
+	return &n
+}
+
+// search returns the index of the first item in n with a key
+// greater than or equal to key, and whether that key equals key.
+func (b *btree[K, V]) search(n *node[K, V], key K) (int, bool) {
+	lo, hi := 0, len(n.items)
+
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+
+		if b.compare(n.items[mid].Key, key) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo, lo < len(n.items) && b.compare(n.items[lo].Key, key) == 0
+}
+
+// insert adds key and val to the subtree rooted at the non-full node n
+// and returns true if the key is new.
+func (b *btree[K, V]) insert(n *node[K, V], key K, val V) bool {
+	for {
+		i, found := b.search(n, key)
+
+		if found {
+			n.items[i].Val = val
+			return false
+		}
+
+		if n.isLeaf() {
+			n.items = insertAt(n.items, i, col.KV[K, V]{Key: key, Val: val})
+			return true
+		}
+
+		if len(n.children[i].items) == b.maxItems() {
+			b.splitChild(n, i)
+
+			switch c := b.compare(key, n.items[i].Key); {
+			case c == 0:
+				n.items[i].Val = val
+				return false
+			case c > 0:
+				i++
+			}
+		}
+
+		n = n.children[i]
+	}
+}
+
+// splitChild splits the full child at index i of n in two,
+// moving the child's median item up into n.
+func (b *btree[K, V]) splitChild(n *node[K, V], i int) {
+	d := b.degree
+	left := n.children[i]
+	right := b.newNode(!left.isLeaf())
+
+	median := left.items[d-1]
+
+	right.items = append(right.items, left.items[d:]...)
+	left.items = truncate(left.items, d-1)
+
+	if !left.isLeaf() {
+		right.children = append(right.children, left.children[d:]...)
+		left.children = truncate(left.children, d)
+	}
+
+	n.items = insertAt(n.items, i, median)
+	n.children = insertAt(n.children, i+1, right)
+}
+
+// delete removes key from the subtree rooted at n and returns true if it was found.
+// Every node visited below the root is first given at least degree items,
+// so removing an item never leaves it underfull.
+func (b *btree[K, V]) delete(n *node[K, V], key K) bool {
+	d := b.degree
+
+	for {
+		i, found := b.search(n, key)
+
+		if n.isLeaf() {
+			if found {
+				n.items = removeAt(n.items, i)
+			}
+
+			return found
+		}
+
+		if found {
+			switch {
+			case len(n.children[i].items) >= d:
+				pred := b.max(n.children[i])
+				n.items[i] = pred
+				n, key = n.children[i], pred.Key
+			case len(n.children[i+1].items) >= d:
+				succ := b.min(n.children[i+1])
+				n.items[i] = succ
+				n, key = n.children[i+1], succ.Key
+			default:
+				b.merge(n, i)
+				n = n.children[i]
+			}
+
+			continue
+		}
+
+		if len(n.children[i].items) < d {
+			i = b.fill(n, i)
+		}
+
+		n = n.children[i]
+	}
+}
+
+// fill gives the child at index i of n at least degree items by borrowing
+// from or merging with a sibling, and returns the child's new index.
+func (b *btree[K, V]) fill(n *node[K, V], i int) int {
+	d := b.degree
+
+	switch {
+	case i > 0 && len(n.children[i-1].items) >= d:
+		b.rotateRight(n, i-1)
+	case i < len(n.children)-1 && len(n.children[i+1].items) >= d:
+		b.rotateLeft(n, i)
+	case i < len(n.children)-1:
+		b.merge(n, i)
+	default:
+		b.merge(n, i-1)
+		i--
+	}
+
+	return i
+}
+
+// rotateRight moves an item from the child at index i of n,
+// through n, into the child at index i+1.
+func (b *btree[K, V]) rotateRight(n *node[K, V], i int) {
+	left, right := n.children[i], n.children[i+1]
+
+	right.items = insertAt(right.items, 0, n.items[i])
+	n.items[i] = left.items[len(left.items)-1]
+	left.items = truncate(left.items, len(left.items)-1)
+
+	if !left.isLeaf() {
+		right.children = insertAt(right.children, 0, left.children[len(left.children)-1])
+		left.children = truncate(left.children, len(left.children)-1)
+	}
+}
+
+// rotateLeft moves an item from the child at index i+1 of n,
+// through n, into the child at index i.
+func (b *btree[K, V]) rotateLeft(n *node[K, V], i int) {
+	left, right := n.children[i], n.children[i+1]
+
+	left.items = append(left.items, n.items[i])
+	n.items[i] = right.items[0]
+	right.items = removeAt(right.items, 0)
+
+	if !right.isLeaf() {
+		left.children = append(left.children, right.children[0])
+		right.children = removeAt(right.children, 0)
+	}
+}
+
+// merge joins the child at index i+1 of n and n's item at index i
+// into the child at index i.
+func (b *btree[K, V]) merge(n *node[K, V], i int) {
+	left, right := n.children[i], n.children[i+1]
+
+	left.items = append(left.items, n.items[i])
+	left.items = append(left.items, right.items...)
+
+	if !left.isLeaf() {
+		left.children = append(left.children, right.children...)
+	}
+
+	n.items = removeAt(n.items, i)
+	n.children = removeAt(n.children, i+1)
+}
+
+func (b *btree[K, V]) min(n *node[K, V]) col.KV[K, V] {
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+
+	return n.items[0]
+}
+
+func (b *btree[K, V]) max(n *node[K, V]) col.KV[K, V] {
+	for !n.isLeaf() {
+		n = n.children[len(n.children)-1]
+	}
+
+	return n.items[len(n.items)-1]
+}
+
+// build returns the root of a tree built bottom-up from sorted, unique items.
+// Items are spread evenly across as few nodes as possible on each level,
+// with one item between each pair of nodes moving up to the next level.
+func (b *btree[K, V]) build(kvs []col.KV[K, V]) *node[K, V] {
+	perNode := 2 * b.degree
+
+	leaves := (len(kvs) + perNode) / perNode
+	nodes := make([]*node[K, V], 0, leaves)
+	seps := make([]col.KV[K, V], 0, leaves-1)
+
+	pos := 0
+
+	for i, size := range split(len(kvs)-(leaves-1), leaves) {
+		leaf := b.newNode(false)
+		leaf.items = append(leaf.items, kvs[pos:pos+size]...)
+		nodes = append(nodes, leaf)
+		pos += size
+
+		if i < leaves-1 {
+			seps = append(seps, kvs[pos])
+			pos++
+		}
+	}
+
+	for len(nodes) > 1 {
+		parents := (len(nodes) + perNode - 1) / perNode
+		nextNodes := make([]*node[K, V], 0, parents)
+		nextSeps := make([]col.KV[K, V], 0, parents-1)
+
+		pos = 0
+
+		for i, size := range split(len(nodes), parents) {
+			parent := b.newNode(true)
+			parent.children = append(parent.children, nodes[pos:pos+size]...)
+			parent.items = append(parent.items, seps[pos:pos+size-1]...)
+			nextNodes = append(nextNodes, parent)
+			pos += size
+
+			if i < parents-1 {
+				nextSeps = append(nextSeps, seps[pos-1])
+			}
+		}
+
+		nodes, seps = nextNodes, nextSeps
+	}
+
+	return nodes[0]
+}
+
+func (b *btree[K, V]) each(n *node[K, V], visit func(key K, val V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	for i, item := range n.items {
+		if !n.isLeaf() && !b.each(n.children[i], visit) {
+			return false
+		}
+
+		if !visit(item.Key, item.Val) {
+			return false
+		}
+	}
+
+	return n.isLeaf() || b.each(n.children[len(n.children)-1], visit)
+}
+
+func (b *btree[K, V]) visitRange(n *node[K, V], lo K, hi K, visit func(key K, val V) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	i, _ := b.search(n, lo)
+
+	for ; i < len(n.items); i++ {
+		if !n.isLeaf() && !b.visitRange(n.children[i], lo, hi, visit) {
+			return false
+		}
+
+		if b.compare(n.items[i].Key, hi) >= 0 {
+			return false
+		}
+
+		if !visit(n.items[i].Key, n.items[i].Val) {
+			return false
+		}
+	}
+
+	return n.isLeaf() || b.visitRange(n.children[i], lo, hi, visit)
+}
+
+// split divides total into parts sizes that differ by at most one.
+func split(total int, parts int) []int {
+	sizes := make([]int, parts)
+
+	for i := range sizes {
+		sizes[i] = total / parts
+
+		if i < total%parts {
+			sizes[i]++
+		}
+	}
+
+	return sizes
+}
+
+func insertAt[E any](s []E, i int, e E) []E {
+	var zero E
+
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = e
+
+	return s
+}
+
+func removeAt[E any](s []E, i int) []E {
+	var zero E
+
+	copy(s[i:], s[i+1:])
+	s[len(s)-1] = zero
+
+	return s[:len(s)-1]
+}
+
+// truncate shortens s to length, clearing the rest for the garbage collector.
+func truncate[E any](s []E, length int) []E {
+	var zero E
+
+	for i := length; i < len(s); i++ {
+		s[i] = zero
+	}
+
+	return s[:length]
+}
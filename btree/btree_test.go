@@ -0,0 +1,313 @@
+package btree
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+// checkInvariants asserts that item counts, key order and leaf depths are valid.
+func checkInvariants[K any, V any](t *testing.T, b *btree[K, V]) {
+	leafDepth := -1
+	count := 0
+
+	var walk func(n *node[K, V], depth int, isRoot bool)
+
+	walk = func(n *node[K, V], depth int, isRoot bool) {
+		count += len(n.items)
+
+		if !isRoot {
+			assert.GreaterOrEqual(t, len(n.items), b.degree-1)
+		} else if !n.isLeaf() {
+			assert.NotEmpty(t, n.items)
+		}
+
+		assert.LessOrEqual(t, len(n.items), b.maxItems())
+
+		for i := 1; i < len(n.items); i++ {
+			assert.Negative(t, b.compare(n.items[i-1].Key, n.items[i].Key))
+		}
+
+		if n.isLeaf() {
+			if leafDepth == -1 {
+				leafDepth = depth
+			}
+
+			assert.Equal(t, leafDepth, depth)
+
+			return
+		}
+
+		assert.Equal(t, len(n.items)+1, len(n.children))
+
+		for _, child := range n.children {
+			walk(child, depth+1, false)
+		}
+	}
+
+	if b.root != nil {
+		walk(b.root, 0, true)
+	}
+
+	assert.Equal(t, b.count, count)
+}
+
+func keys[K any, V any](b *btree[K, V]) []K {
+	result := make([]K, 0)
+
+	b.Each(func(key K, _ V) bool {
+		result = append(result, key)
+		return true
+	})
+
+	return result
+}
+
+func TestNew_WithZeroDegree_UsesDefault(t *testing.T) {
+	b := New[int, int](0)
+
+	assert.Equal(t, DefaultDegree, b.Degree())
+}
+
+func TestNew_WithDegreeOne_Panics(t *testing.T) {
+	assert.Panics(t, func() { New[int, int](1) })
+}
+
+func TestInsert_WithExistingKey_ReplacesValue(t *testing.T) {
+	b := New[string, int](2)
+
+	b.Insert("a", 1)
+	b.Insert("a", 2)
+
+	val, e := b.Get("a")
+
+	assert.Nil(t, e)
+	assert.Equal(t, 2, val)
+	assert.Equal(t, 1, b.Count())
+}
+
+func TestGet_WithMissingKey_ReturnsError(t *testing.T) {
+	b := New[string, int](2)
+
+	b.Insert("a", 1)
+
+	_, e := b.Get("b")
+
+	assert.NotNil(t, e)
+	assert.False(t, b.Contains("b"))
+}
+
+func TestDelete_WithMissingKey_ReturnsError(t *testing.T) {
+	b := New[int, int](2)
+
+	assert.NotNil(t, b.Delete(1))
+
+	b.Insert(1, 1)
+
+	assert.NotNil(t, b.Delete(2))
+}
+
+func TestDelete_WithMissingKeyAfterMerge_ShrinksRoot(t *testing.T) {
+	b := New[int, int](2)
+
+	for i := 0; i < 4; i++ {
+		b.Insert(i, i)
+	}
+
+	assert.Nil(t, b.Delete(3))
+	assert.NotNil(t, b.Delete(99))
+
+	checkInvariants(t, b)
+	assert.Equal(t, 1, b.Height())
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, b.Delete(i))
+		checkInvariants(t, b)
+	}
+
+	assert.True(t, b.IsEmpty())
+}
+
+func TestDeleteAndDeleteRange_WithRandomKeys_KeepsInvariants(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b := New[int, int](2)
+	expected := map[int]bool{}
+
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(200)
+
+		switch r.Intn(4) {
+		case 0:
+			_, ok := expected[key]
+			delete(expected, key)
+			assert.Equal(t, ok, b.Delete(key) == nil)
+		case 1:
+			hi := key + r.Intn(10)
+			removed := 0
+
+			for k := key; k < hi; k++ {
+				if expected[k] {
+					delete(expected, k)
+					removed++
+				}
+			}
+
+			assert.Equal(t, removed, b.DeleteRange(key, hi))
+		default:
+			expected[key] = true
+			b.Insert(key, i)
+		}
+
+		checkInvariants(t, b)
+		assert.Equal(t, len(expected), b.Count())
+	}
+}
+
+func TestInsertAndDelete_WithRandomKeys_MatchesMap(t *testing.T) {
+	for _, degree := range []int{2, 3, 5, 16} {
+		r := rand.New(rand.NewSource(int64(degree)))
+		b := New[int, int](degree)
+		expected := map[int]int{}
+
+		for i := 0; i < 3000; i++ {
+			key := r.Intn(1000)
+
+			if r.Intn(3) == 0 {
+				_, ok := expected[key]
+				delete(expected, key)
+				assert.Equal(t, ok, b.Delete(key) == nil)
+			} else {
+				expected[key] = i
+				b.Insert(key, i)
+			}
+
+			if i%10 == 0 {
+				checkInvariants(t, b)
+			}
+		}
+
+		checkInvariants(t, b)
+
+		for key, val := range expected {
+			actual, e := b.Get(key)
+			assert.Nil(t, e)
+			assert.Equal(t, val, actual)
+		}
+
+		assert.Equal(t, len(expected), b.Count())
+		assert.True(t, sort.IntsAreSorted(keys(b)))
+	}
+}
+
+func TestEach_WithVisitReturningFalse_Stops(t *testing.T) {
+	b := New[int, int](2)
+
+	for i := 0; i < 20; i++ {
+		b.Insert(i, i)
+	}
+
+	visited := 0
+
+	b.Each(func(key int, _ int) bool {
+		visited++
+		return key < 4
+	})
+
+	assert.Equal(t, 5, visited)
+}
+
+func TestRange_WithBounds_VisitsInOrder(t *testing.T) {
+	b := New[int, int](2)
+
+	for i := 0; i < 50; i += 2 {
+		b.Insert(i, i)
+	}
+
+	visited := make([]int, 0)
+
+	b.Range(7, 16, func(key int, _ int) bool {
+		visited = append(visited, key)
+		return true
+	})
+
+	assert.Equal(t, []int{8, 10, 12, 14}, visited)
+}
+
+func TestDeleteRange_WithBounds_RemovesKeys(t *testing.T) {
+	b := New[int, int](3)
+
+	for i := 0; i < 100; i++ {
+		b.Insert(i, i)
+	}
+
+	removed := b.DeleteRange(10, 90)
+
+	assert.Equal(t, 80, removed)
+	assert.Equal(t, 20, b.Count())
+	assert.False(t, b.Contains(50))
+	assert.True(t, b.Contains(90))
+	checkInvariants(t, b)
+}
+
+func TestLoad_WithEmptyTree_BuildsValidTree(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 4, 7, 8, 100, 1000} {
+		b := New[int, int](3)
+		kvs := make([]col.KV[int, int], 0, n)
+
+		for i := 0; i < n; i++ {
+			kvs = append(kvs, col.KV[int, int]{Key: i, Val: i})
+		}
+
+		b.Load(kvs)
+
+		checkInvariants(t, b)
+		assert.Equal(t, n, b.Count())
+
+		for i := 0; i < n; i += 2 {
+			b.Delete(i)
+		}
+
+		checkInvariants(t, b)
+		assert.Equal(t, n/2, b.Count())
+	}
+}
+
+func TestLoad_WithNonEmptyTree_InsertsValues(t *testing.T) {
+	b := New[int, int](2)
+
+	b.Insert(5, 5)
+	b.Load([]col.KV[int, int]{{Key: 1, Val: 1}, {Key: 9, Val: 9}})
+
+	assert.Equal(t, []int{1, 5, 9}, keys(b))
+}
+
+func TestLoad_WithUnsortedKeys_Panics(t *testing.T) {
+	b := New[int, int](2)
+
+	assert.Panics(t, func() {
+		b.Load([]col.KV[int, int]{{Key: 2, Val: 2}, {Key: 1, Val: 1}})
+	})
+}
+
+func TestHeight_WithManyKeys_IsLogarithmic(t *testing.T) {
+	b := New[int, int](16)
+
+	for i := 0; i < 10000; i++ {
+		b.Insert(i, i)
+	}
+
+	assert.LessOrEqual(t, b.Height(), 4)
+}
+
+func TestClear_WithKeys_IsEmpty(t *testing.T) {
+	b := New[int, int](2)
+
+	b.Insert(1, 1)
+	b.Clear()
+
+	assert.True(t, b.IsEmpty())
+	assert.Zero(t, b.Height())
+}
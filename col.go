@@ -1,5 +1,7 @@
 package col
 
+import "golang.org/x/exp/constraints"
+
 // A KeyValue data structure.
 type KV[K any, T any] struct {
 	Key K
@@ -11,3 +13,8 @@ type PV[T any] struct {
 	Priority int
 	Val      T
 }
+
+// A Number is any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
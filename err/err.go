@@ -15,3 +15,27 @@ type NotFound struct{}
 func (e *NotFound) Error() string {
 	return "Value not found."
 }
+
+type Empty struct{}
+
+func (e *Empty) Error() string {
+	return "Collection is empty."
+}
+
+type Full struct{}
+
+func (e *Full) Error() string {
+	return "Collection is full."
+}
+
+type Closed struct{}
+
+func (e *Closed) Error() string {
+	return "Collection is closed."
+}
+
+type NotReady struct{}
+
+func (e *NotReady) Error() string {
+	return "Value is not ready."
+}
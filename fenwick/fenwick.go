@@ -0,0 +1,146 @@
+// Package fenwick provides Fenwick tree, or binary indexed tree,
+// data structures for prefix sums over numeric values.
+// A Fenwick tree provides two main operations:
+//
+//  1. Add which adds a delta to a single value.
+//  2. PrefixSum which sums all values before an index.
+//
+// Each operation takes O(log n) time.
+//
+// This Fenwick tree data structure is NOT thread-safe.
+package fenwick
+
+import "github.com/sgago/col"
+
+// A Fenwick tree data structure with type T values.
+type fenwick[T col.Number] struct {
+	// tree[i-1] holds the sum of the values in (i - lowbit(i), i].
+	tree []T
+}
+
+// New allocates and initializes a new Fenwick tree of n zero values.
+func New[T col.Number](n int) *fenwick[T] {
+	return &fenwick[T]{tree: make([]T, n)}
+}
+
+// FromSlice allocates and initializes a new Fenwick tree
+// over a copy of values in O(n) time.
+func FromSlice[T col.Number](values []T) *fenwick[T] {
+	f := fenwick[T]{tree: make([]T, len(values))}
+
+	copy(f.tree, values)
+
+	for i := 1; i <= len(f.tree); i++ {
+		if parent := i + lowbit(i); parent <= len(f.tree) {
+			f.tree[parent-1] += f.tree[i-1]
+		}
+	}
+
+	return &f
+}
+
+// Add adds delta to the value at index.
+//
+// This method panics if index is out of bounds.
+func (f *fenwick[T]) Add(index int, delta T) {
+	if index < 0 || index >= len(f.tree) {
+		panic("The index is out of bounds.")
+	}
+
+	for i := index + 1; i <= len(f.tree); i += lowbit(i) {
+		f.tree[i-1] += delta
+	}
+}
+
+// PrefixSum returns the sum of the values in [0, end).
+//
+// This method panics if end is out of bounds.
+func (f *fenwick[T]) PrefixSum(end int) T {
+	if end < 0 || end > len(f.tree) {
+		panic("The index is out of bounds.")
+	}
+
+	var sum T
+
+	for i := end; i > 0; i -= lowbit(i) {
+		sum += f.tree[i-1]
+	}
+
+	return sum
+}
+
+// RangeSum returns the sum of the values in [l, r).
+//
+// This method panics if the range is out of bounds.
+func (f *fenwick[T]) RangeSum(l int, r int) T {
+	if l > r {
+		panic("The range is out of bounds.")
+	}
+
+	return f.PrefixSum(r) - f.PrefixSum(l)
+}
+
+// Get returns the value at index.
+//
+// This method panics if index is out of bounds.
+func (f *fenwick[T]) Get(index int) T {
+	return f.RangeSum(index, index+1)
+}
+
+// LowerBound returns the smallest index where the prefix sum through
+// that index, PrefixSum(index+1), is greater than or equal to target.
+// If no such index exists, LowerBound returns Count().
+//
+// For example, with weights 1, 3, and 2, targets in (0, 1] return 0,
+// targets in (1, 4] return 1, and targets in (4, 6] return 2,
+// which makes LowerBound useful for weighted random sampling.
+//
+// All values must be non-negative.
+func (f *fenwick[T]) LowerBound(target T) int {
+	if target <= 0 {
+		return 0
+	}
+
+	pos := 0
+
+	for step := highbit(len(f.tree)); step > 0; step >>= 1 {
+		if next := pos + step; next <= len(f.tree) && f.tree[next-1] < target {
+			pos = next
+			target -= f.tree[next-1]
+		}
+	}
+
+	return pos
+}
+
+// Count returns the number of values in the tree.
+func (f *fenwick[T]) Count() int {
+	return len(f.tree)
+}
+
+// Clear sets all values in the tree to zero.
+func (f *fenwick[T]) Clear() {
+	for i := range f.tree {
+		f.tree[i] = 0
+	}
+}
+
+// lowbit returns the lowest set bit of i.
+func lowbit(i int) int {
+	return i & -i
+}
+
+// highbit returns the highest set bit of n, or zero if n is zero.
+func highbit(n int) int {
+	if n == 0 {
+		return 0
+	}
+
+	bit := 1
+
+	for bit <= n/2 {
+		bit <<= 1
+	}
+
+	return bit
+}
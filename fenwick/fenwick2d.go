@@ -0,0 +1,85 @@
+package fenwick
+
+import "github.com/sgago/col"
+
+// A two-dimensional Fenwick tree data structure with type T values.
+type fenwick2d[T col.Number] struct {
+	rows int
+	cols int
+	tree []T
+}
+
+// New2D allocates and initializes a new two-dimensional
+// Fenwick tree of rows by cols zero values.
+func New2D[T col.Number](rows int, cols int) *fenwick2d[T] {
+	return &fenwick2d[T]{
+		rows: rows,
+		cols: cols,
+		tree: make([]T, rows*cols),
+	}
+}
+
+// Add adds delta to the value at row and column.
+//
+// This method panics if row or column is out of bounds.
+func (f *fenwick2d[T]) Add(row int, column int, delta T) {
+	if row < 0 || row >= f.rows || column < 0 || column >= f.cols {
+		panic("The index is out of bounds.")
+	}
+
+	for i := row + 1; i <= f.rows; i += lowbit(i) {
+		for j := column + 1; j <= f.cols; j += lowbit(j) {
+			f.tree[(i-1)*f.cols+j-1] += delta
+		}
+	}
+}
+
+// PrefixSum returns the sum of the values in rows [0, endRow) and cols [0, endCol).
+//
+// This method panics if endRow or endCol is out of bounds.
+func (f *fenwick2d[T]) PrefixSum(endRow int, endCol int) T {
+	if endRow < 0 || endRow > f.rows || endCol < 0 || endCol > f.cols {
+		panic("The index is out of bounds.")
+	}
+
+	var sum T
+
+	for i := endRow; i > 0; i -= lowbit(i) {
+		for j := endCol; j > 0; j -= lowbit(j) {
+			sum += f.tree[(i-1)*f.cols+j-1]
+		}
+	}
+
+	return sum
+}
+
+// RangeSum returns the sum of the values in rows [row1, row2) and cols [col1, col2).
+//
+// This method panics if the range is out of bounds.
+func (f *fenwick2d[T]) RangeSum(row1 int, col1 int, row2 int, col2 int) T {
+	if row1 > row2 || col1 > col2 {
+		panic("The range is out of bounds.")
+	}
+
+	return f.PrefixSum(row2, col2) -
+		f.PrefixSum(row1, col2) -
+		f.PrefixSum(row2, col1) +
+		f.PrefixSum(row1, col1)
+}
+
+// Rows returns the number of rows in the tree.
+func (f *fenwick2d[T]) Rows() int {
+	return f.rows
+}
+
+// Cols returns the number of columns in the tree.
+func (f *fenwick2d[T]) Cols() int {
+	return f.cols
+}
+
+// Clear sets all values in the tree to zero.
+func (f *fenwick2d[T]) Clear() {
+	for i := range f.tree {
+		f.tree[i] = 0
+	}
+}
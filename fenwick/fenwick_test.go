@@ -0,0 +1,131 @@
+package fenwick
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_WithSize_SumsAreZero(t *testing.T) {
+	f := New[int](5)
+
+	assert.Equal(t, 5, f.Count())
+	assert.Zero(t, f.PrefixSum(5))
+}
+
+func TestFromSlice_WithValues_PrefixSumsAreCorrect(t *testing.T) {
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	f := FromSlice(values)
+
+	expected := 0
+
+	for i := 0; i <= len(values); i++ {
+		assert.Equal(t, expected, f.PrefixSum(i))
+
+		if i < len(values) {
+			expected += values[i]
+		}
+	}
+}
+
+func TestAdd_WithDelta_RangeSumIsCorrect(t *testing.T) {
+	f := FromSlice([]float64{1, 2, 3, 4})
+
+	f.Add(2, 0.5)
+
+	assert.Equal(t, 5.5, f.RangeSum(1, 3))
+	assert.Equal(t, 3.5, f.Get(2))
+}
+
+func TestAdd_WithOutOfBoundsIndex_Panics(t *testing.T) {
+	f := New[int](3)
+
+	assert.Panics(t, func() { f.Add(3, 1) })
+	assert.Panics(t, func() { f.PrefixSum(4) })
+}
+
+func TestLowerBound_WithWeights_ReturnsIndex(t *testing.T) {
+	f := FromSlice([]int{1, 3, 2})
+
+	assert.Equal(t, 0, f.LowerBound(1))
+	assert.Equal(t, 1, f.LowerBound(2))
+	assert.Equal(t, 1, f.LowerBound(4))
+	assert.Equal(t, 2, f.LowerBound(5))
+	assert.Equal(t, 2, f.LowerBound(6))
+	assert.Equal(t, 3, f.LowerBound(7))
+}
+
+func TestLowerBound_WithRandomWeights_MatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int, 37)
+
+	for i := range values {
+		values[i] = r.Intn(5)
+	}
+
+	f := FromSlice(values)
+
+	for target := 1; target < 100; target++ {
+		expected, sum := len(values), 0
+
+		for i, v := range values {
+			sum += v
+
+			if sum >= target {
+				expected = i
+				break
+			}
+		}
+
+		assert.Equal(t, expected, f.LowerBound(target))
+	}
+}
+
+func TestClear_WithValues_SumsAreZero(t *testing.T) {
+	f := FromSlice([]int{1, 2, 3})
+
+	f.Clear()
+
+	assert.Zero(t, f.PrefixSum(3))
+}
+
+func TestNew2D_WithAdds_RangeSumsMatchGrid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rows, cols := 6, 9
+	grid := make([][]int, rows)
+	f := New2D[int](rows, cols)
+
+	for i := range grid {
+		grid[i] = make([]int, cols)
+	}
+
+	for i := 0; i < 100; i++ {
+		row, col, delta := r.Intn(rows), r.Intn(cols), r.Intn(10)
+		grid[row][col] += delta
+		f.Add(row, col, delta)
+	}
+
+	for i := 0; i < 100; i++ {
+		row1, col1 := r.Intn(rows+1), r.Intn(cols+1)
+		row2, col2 := row1+r.Intn(rows+1-row1), col1+r.Intn(cols+1-col1)
+
+		expected := 0
+
+		for row := row1; row < row2; row++ {
+			for col := col1; col < col2; col++ {
+				expected += grid[row][col]
+			}
+		}
+
+		assert.Equal(t, expected, f.RangeSum(row1, col1, row2, col2))
+	}
+}
+
+func TestNew2D_WithOutOfBoundsIndex_Panics(t *testing.T) {
+	f := New2D[int](2, 3)
+
+	assert.Equal(t, 2, f.Rows())
+	assert.Equal(t, 3, f.Cols())
+	assert.Panics(t, func() { f.Add(0, 3, 1) })
+}
@@ -2,7 +2,10 @@ module github.com/sgago/col
 
 go 1.18
 
-require github.com/stretchr/testify v1.7.4
+require (
+	github.com/stretchr/testify v1.7.4
+	golang.org/x/exp v0.0.0-20220722155223-a9213eeb770e
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,147 @@
+package heap
+
+import "github.com/sgago/col/slice"
+
+// TopK returns the k greatest values of vals, ordered by less, from greatest to least.
+// It keeps a heap of at most k values, which takes O(n log k) time and O(k) space.
+// If vals has fewer than k values, all of them are returned.
+//
+// This function panics if k is negative or less is nil.
+func TopK[T any](vals []T, k int, less func(a T, b T) bool) []T {
+	if k < 0 {
+		panic("The k must be at least 0.")
+	}
+
+	if k > len(vals) {
+		k = len(vals)
+	}
+
+	// The top of the heap is the least of the greatest values seen so far.
+	h := NewFunc(less, k)
+
+	for _, val := range vals {
+		if h.Count() < k {
+			h.Push(val)
+		} else if k > 0 && less(h.Peek(), val) {
+			h.elems[0] = val
+			h.siftDown(0)
+		}
+	}
+
+	top := make([]T, h.Count())
+
+	for i := len(top) - 1; i >= 0; i-- {
+		top[i] = h.Pop()
+	}
+
+	return top
+}
+
+// Sort sorts vals in place, ordered by less, using heap sort.
+// It takes O(n log n) time and O(1) extra space, and is not stable.
+//
+// This function panics if less is nil.
+func Sort[T any](vals []T, less func(a T, b T) bool) {
+	if less == nil {
+		panic("The less function cannot be nil.")
+	}
+
+	// A heap with the greatest value on top shares the backing array of vals.
+	h := funcheap[T]{
+		elems: vals,
+		less:  func(a T, b T) bool { return less(b, a) },
+	}
+
+	for i := len(h.elems)/2 - 1; i >= 0; i-- {
+		h.siftDown(i)
+	}
+
+	for end := len(vals) - 1; end > 0; end-- {
+		vals = slice.Swap(vals, 0, end)
+		h.elems = h.elems[:end]
+		h.siftDown(0)
+	}
+}
+
+// MergeSorted merges slices, each already ordered by less,
+// into one new slice ordered by less in O(n log k) time.
+//
+// This function panics if less is nil.
+func MergeSorted[T any](less func(a T, b T) bool, slices ...[]T) []T {
+	nexts := make([]func() (T, bool), len(slices))
+	total := 0
+
+	for i, s := range slices {
+		nexts[i] = iterate(s)
+		total += len(s)
+	}
+
+	merged := make([]T, 0, total)
+	next := Merge(less, nexts...)
+
+	for val, ok := next(); ok; val, ok = next() {
+		merged = append(merged, val)
+	}
+
+	return merged
+}
+
+// Merge lazily merges sequences, each already ordered by less, into one sequence
+// ordered by less. Each sequence is a next function that returns its next value
+// and true, or false once it is exhausted; Merge returns the same kind of function.
+// The merged sequence keeps a heap of one cursor per sequence,
+// so each value takes O(log k) time.
+//
+// This function panics if less is nil.
+func Merge[T any](less func(a T, b T) bool, nexts ...func() (T, bool)) func() (T, bool) {
+	if less == nil {
+		panic("The less function cannot be nil.")
+	}
+
+	type cursor struct {
+		val  T
+		next func() (T, bool)
+	}
+
+	h := NewFunc(func(a cursor, b cursor) bool { return less(a.val, b.val) }, len(nexts))
+
+	for _, next := range nexts {
+		if val, ok := next(); ok {
+			h.Push(cursor{val: val, next: next})
+		}
+	}
+
+	return func() (T, bool) {
+		if h.IsEmpty() {
+			var zero T
+			return zero, false
+		}
+
+		top := h.Peek()
+
+		if val, ok := top.next(); ok {
+			h.elems[0].val = val
+			h.siftDown(0)
+		} else {
+			h.Pop()
+		}
+
+		return top.val, true
+	}
+}
+
+// iterate returns a next function over the values of s.
+func iterate[T any](s []T) func() (T, bool) {
+	i := 0
+
+	return func() (T, bool) {
+		if i == len(s) {
+			var zero T
+			return zero, false
+		}
+
+		i++
+
+		return s[i-1], true
+	}
+}
@@ -0,0 +1,94 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intLess(a int, b int) bool {
+	return a < b
+}
+
+func TestTopK_WithValues_ReturnsGreatestDescending(t *testing.T) {
+	top := TopK([]int{5, 1, 9, 3, 7, 2}, 3, intLess)
+
+	assert.Equal(t, []int{9, 7, 5}, top)
+}
+
+func TestTopK_WithKGreaterThanCount_ReturnsAllDescending(t *testing.T) {
+	top := TopK([]int{2, 3, 1}, 10, intLess)
+
+	assert.Equal(t, []int{3, 2, 1}, top)
+}
+
+func TestTopK_WithZeroK_ReturnsEmpty(t *testing.T) {
+	top := TopK([]int{2, 3, 1}, 0, intLess)
+
+	assert.Empty(t, top)
+}
+
+func TestTopK_WithNegativeK_Panics(t *testing.T) {
+	assert.Panics(t, func() { TopK([]int{1}, -1, intLess) })
+}
+
+func TestSort_WithRandomValues_IsSorted(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 50; n++ {
+		vals := make([]int, n)
+
+		for i := range vals {
+			vals[i] = r.Intn(20)
+		}
+
+		expected := make([]int, n)
+		copy(expected, vals)
+		sort.Ints(expected)
+
+		Sort(vals, intLess)
+
+		assert.Equal(t, expected, vals)
+	}
+}
+
+func TestSort_WithNilLess_Panics(t *testing.T) {
+	assert.Panics(t, func() { Sort([]int{2, 1}, nil) })
+}
+
+func TestMergeSorted_WithSortedSlices_ReturnsMergedSlice(t *testing.T) {
+	merged := MergeSorted(intLess, []int{1, 4, 7}, []int{}, []int{2, 5, 8, 9}, []int{3, 6})
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, merged)
+}
+
+func TestMergeSorted_WithNoSlices_ReturnsEmpty(t *testing.T) {
+	merged := MergeSorted(intLess)
+
+	assert.Empty(t, merged)
+}
+
+func TestMerge_WithNextFunctions_MergesLazily(t *testing.T) {
+	calls := 0
+	counting := func() (int, bool) {
+		calls++
+		return calls * 10, calls <= 3
+	}
+
+	next := Merge(intLess, iterate([]int{5, 15, 25}), counting)
+
+	val, ok := next()
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+	assert.Equal(t, 1, calls)
+
+	rest := make([]int, 0)
+
+	for val, ok := next(); ok; val, ok = next() {
+		rest = append(rest, val)
+	}
+
+	assert.Equal(t, []int{10, 15, 20, 25, 30}, rest)
+}
@@ -0,0 +1,133 @@
+package heap
+
+import "github.com/sgago/col"
+
+// The eviction policy of a bounded heap, that is, what Push does when the heap is full.
+type EvictionPolicy int
+
+const (
+	// Indicates that Push rejects new elements when the heap is full.
+	Reject EvictionPolicy = iota
+
+	// Indicates that Push evicts the element with the lowest priority when the heap is full.
+	EvictLowest
+
+	// Indicates that Push evicts the element with the highest priority when the heap is full.
+	EvictHighest
+)
+
+// A slice-backed, capacity-limited min or max heap data structure with type T values.
+// It is built on a min-max heap, so Push, Pop and eviction all take O(log n) time.
+type boundedheap[T any] struct {
+	elems  *minmaxheap[T]
+	sort   HeapSort
+	limit  int
+	policy EvictionPolicy
+}
+
+// NewBounded allocates and initializes a new min or max heap with type T values
+// that holds at most limit elements, applying policy when Push finds the heap full.
+//
+// This function panics if limit is less than one or
+// if more than limit values are supplied.
+func NewBounded[T any](sort HeapSort, limit int, policy EvictionPolicy, pvs ...col.PV[T]) *boundedheap[T] {
+	if limit < 1 {
+		panic("The limit must be at least 1.")
+	}
+
+	if len(pvs) > limit {
+		panic("The values exceed the limit.")
+	}
+
+	return &boundedheap[T]{
+		elems:  NewMinMax(limit, pvs...),
+		sort:   sort,
+		limit:  limit,
+		policy: policy,
+	}
+}
+
+// Push adds an element to the heap. If the heap is full, Push applies the
+// eviction policy and returns the element that was evicted or rejected and true;
+// otherwise, it returns false.
+//
+// The new element takes part in eviction, so when it would be the evicted
+// element itself, it is returned and the heap is left unchanged.
+func (h *boundedheap[T]) Push(pv col.PV[T]) (col.PV[T], bool) {
+	if !h.IsFull() {
+		h.elems.Push(pv)
+		return col.PV[T]{}, false
+	}
+
+	switch h.policy {
+	case EvictLowest:
+		if pv.Priority <= h.elems.PeekMin().Priority {
+			return pv, true
+		}
+
+		evicted := h.elems.PopMin()
+		h.elems.Push(pv)
+
+		return evicted, true
+	case EvictHighest:
+		if pv.Priority >= h.elems.PeekMax().Priority {
+			return pv, true
+		}
+
+		evicted := h.elems.PopMax()
+		h.elems.Push(pv)
+
+		return evicted, true
+	default:
+		return pv, true
+	}
+}
+
+// Pop removes and returns the top element of the heap.
+//
+// This method panics if the heap is empty.
+func (h *boundedheap[T]) Pop() col.PV[T] {
+	if h.sort == Min {
+		return h.elems.PopMin()
+	}
+
+	return h.elems.PopMax()
+}
+
+// Peek returns the top element of the heap.
+//
+// This method panics if the heap is empty.
+func (h *boundedheap[T]) Peek() col.PV[T] {
+	if h.sort == Min {
+		return h.elems.PeekMin()
+	}
+
+	return h.elems.PeekMax()
+}
+
+// Count returns the number of elements in the heap.
+func (h *boundedheap[T]) Count() int {
+	return h.elems.Count()
+}
+
+// Limit returns the maximum number of elements in the heap.
+func (h *boundedheap[T]) Limit() int {
+	return h.limit
+}
+
+// IsFull returns true if the heap holds limit elements;
+// otherwise, false.
+func (h *boundedheap[T]) IsFull() bool {
+	return h.Count() >= h.limit
+}
+
+// IsEmpty returns true if the heap has no elements;
+// otherwise, false.
+func (h *boundedheap[T]) IsEmpty() bool {
+	return h.Count() == 0
+}
+
+// Clear removes all elements from the heap.
+func (h *boundedheap[T]) Clear() {
+	h.elems.Clear()
+}
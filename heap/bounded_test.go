@@ -0,0 +1,91 @@
+package heap
+
+import (
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBounded_WithInvalidLimit_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewBounded[int](Min, 0, Reject) })
+	assert.Panics(t, func() { NewBounded(Min, 1, Reject, pv1, pv2) })
+}
+
+func TestPush_WithRoom_DoesNotEvict(t *testing.T) {
+	h := NewBounded(Min, 2, Reject, pv2)
+
+	_, evicted := h.Push(pv1)
+
+	assert.False(t, evicted)
+	assert.True(t, h.IsFull())
+	assert.Equal(t, pv1, h.Peek())
+}
+
+func TestPush_WithFullRejectHeap_ReturnsNewElement(t *testing.T) {
+	h := NewBounded(Min, 2, Reject, pv2, pv3)
+
+	pv, evicted := h.Push(pv1)
+
+	assert.True(t, evicted)
+	assert.Equal(t, pv1, pv)
+	assert.Equal(t, 2, h.Count())
+	assert.Equal(t, pv2, h.Peek())
+}
+
+func TestPush_WithFullEvictLowestHeap_EvictsLowest(t *testing.T) {
+	h := NewBounded(Max, 2, EvictLowest, pv2, pv3)
+
+	pv, evicted := h.Push(pv4)
+
+	assert.True(t, evicted)
+	assert.Equal(t, pv2, pv)
+	assert.Equal(t, pv4, h.Pop())
+	assert.Equal(t, pv3, h.Pop())
+}
+
+func TestPush_WithFullEvictLowestHeapAndLowerElement_ReturnsNewElement(t *testing.T) {
+	h := NewBounded(Min, 2, EvictLowest, pv2, pv3)
+
+	pv, evicted := h.Push(pv1)
+
+	assert.True(t, evicted)
+	assert.Equal(t, pv1, pv)
+	assert.Equal(t, pv2, h.Peek())
+}
+
+func TestPush_WithFullEvictHighestHeap_EvictsHighest(t *testing.T) {
+	h := NewBounded(Min, 2, EvictHighest, pv2, pv4)
+
+	pv, evicted := h.Push(pv1)
+
+	assert.True(t, evicted)
+	assert.Equal(t, pv4, pv)
+	assert.Equal(t, pv1, h.Pop())
+	assert.Equal(t, pv2, h.Pop())
+}
+
+func TestPush_WithEvictLowestHeap_TracksTopN(t *testing.T) {
+	h := NewBounded[int](Max, 3, EvictLowest)
+
+	for _, priority := range []int{5, 1, 9, 3, 7, 2, 8} {
+		h.Push(col.PV[int]{Priority: priority})
+	}
+
+	values := make([]int, 0, 3)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{9, 8, 7}, values)
+}
+
+func TestClear_WithBoundedHeap_IsEmpty(t *testing.T) {
+	h := NewBounded(Min, 2, Reject, pv1, pv2)
+
+	h.Clear()
+
+	assert.True(t, h.IsEmpty())
+	assert.Equal(t, 2, h.Limit())
+}
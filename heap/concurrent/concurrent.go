@@ -0,0 +1,285 @@
+// Package concurrent provides a slice-backed, thread-safe,
+// blocking priority queue built on the heap package.
+// A blocking priority queue provides two main operations:
+//
+//  1. Push which adds an element, waiting for room if the queue is bounded and full.
+//  2. Pop which removes and returns the top element, waiting for one if the queue is empty.
+//
+// Both operations stop waiting when their context is cancelled.
+// After Close, Push fails and Pop drains the remaining elements
+// before failing, which allows for graceful shutdown.
+package concurrent
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+	"github.com/sgago/col/heap"
+)
+
+// The heap operations used by the priority queue.
+type priorityQueue[T any] interface {
+	Push(pv col.PV[T])
+	Pop() col.PV[T]
+	Peek() col.PV[T]
+	Count() int
+	Clear()
+}
+
+// A slice-backed, thread-safe, blocking priority queue with type T values.
+type concheap[T any] struct {
+	heap   priorityQueue[T]
+	limit  int
+	closed bool
+	mu     sync.Mutex
+
+	// poppers holds a channel for each Pop waiting for an element,
+	// and pushers one for each Push waiting for room, in arrival order.
+	// Each Push or Pop wakes at most one waiter of the other kind.
+	poppers list.List
+	pushers list.List
+}
+
+// New allocates and initializes a new, unbounded min or max priority queue
+// with type T values.
+func New[T any](sort heap.HeapSort, capacity int, pvs ...col.PV[T]) *concheap[T] {
+	return &concheap[T]{
+		heap: heap.New(sort, capacity, pvs...),
+	}
+}
+
+// NewBounded allocates and initializes a new min or max priority queue
+// with type T values that holds at most limit elements.
+// Push waits while the queue is full.
+//
+// This function panics if limit is less than one or
+// if more than limit values are supplied.
+func NewBounded[T any](sort heap.HeapSort, limit int, pvs ...col.PV[T]) *concheap[T] {
+	if limit < 1 {
+		panic("The limit must be at least 1.")
+	}
+
+	if len(pvs) > limit {
+		panic("The values exceed the limit.")
+	}
+
+	return &concheap[T]{
+		heap:  heap.New(sort, limit, pvs...),
+		limit: limit,
+	}
+}
+
+// Push adds an element to the queue.
+// If the queue is bounded and full, Push waits until there is room.
+//
+// Push returns an error if the queue is closed or
+// the context is cancelled before the element is added.
+func (h *concheap[T]) Push(ctx context.Context, pv col.PV[T]) error {
+	for {
+		h.mu.Lock()
+
+		if h.closed {
+			h.mu.Unlock()
+			return &err.Closed{}
+		}
+
+		if !h.isFull() {
+			h.heap.Push(pv)
+			wakeOne(&h.poppers)
+			h.mu.Unlock()
+
+			return nil
+		}
+
+		if e := h.wait(ctx, &h.pushers); e != nil {
+			return e
+		}
+	}
+}
+
+// TryPush adds an element to the queue without waiting.
+//
+// TryPush returns an error if the queue is closed or full.
+func (h *concheap[T]) TryPush(pv col.PV[T]) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return &err.Closed{}
+	}
+
+	if h.isFull() {
+		return &err.Full{}
+	}
+
+	h.heap.Push(pv)
+	wakeOne(&h.poppers)
+
+	return nil
+}
+
+// Pop removes and returns the top element of the queue.
+// If the queue is empty, Pop waits until an element is pushed.
+//
+// Pop returns an error if the queue is closed and empty or
+// the context is cancelled before an element is available.
+func (h *concheap[T]) Pop(ctx context.Context) (col.PV[T], error) {
+	for {
+		h.mu.Lock()
+
+		if h.heap.Count() > 0 {
+			pv := h.heap.Pop()
+			wakeOne(&h.pushers)
+			h.mu.Unlock()
+
+			return pv, nil
+		}
+
+		if h.closed {
+			h.mu.Unlock()
+			return col.PV[T]{}, &err.Closed{}
+		}
+
+		if e := h.wait(ctx, &h.poppers); e != nil {
+			return col.PV[T]{}, e
+		}
+	}
+}
+
+// TryPop removes and returns the top element of the queue without waiting.
+//
+// TryPop returns an error if the queue is empty,
+// or if the queue is closed and empty.
+func (h *concheap[T]) TryPop() (col.PV[T], error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.heap.Count() == 0 {
+		if h.closed {
+			return col.PV[T]{}, &err.Closed{}
+		}
+
+		return col.PV[T]{}, &err.Empty{}
+	}
+
+	pv := h.heap.Pop()
+	wakeOne(&h.pushers)
+
+	return pv, nil
+}
+
+// Peek returns the top element of the queue without removing it.
+//
+// Peek returns an error if the queue is empty.
+func (h *concheap[T]) Peek() (col.PV[T], error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.heap.Count() == 0 {
+		return col.PV[T]{}, &err.Empty{}
+	}
+
+	return h.heap.Peek(), nil
+}
+
+// Close stops the queue from accepting new elements and wakes all waiters.
+// Elements already in the queue can still be popped.
+// Closing a closed queue does nothing.
+func (h *concheap[T]) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.closed {
+		h.closed = true
+		wakeAll(&h.poppers)
+		wakeAll(&h.pushers)
+	}
+}
+
+// IsClosed returns true if the queue has been closed;
+// otherwise, false.
+func (h *concheap[T]) IsClosed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.closed
+}
+
+// Count returns the number of elements in the queue.
+func (h *concheap[T]) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.heap.Count()
+}
+
+// Limit returns the maximum number of elements in the queue,
+// or zero if the queue is unbounded.
+func (h *concheap[T]) Limit() int {
+	return h.limit
+}
+
+// IsEmpty returns true if the queue has no elements;
+// otherwise, false.
+func (h *concheap[T]) IsEmpty() bool {
+	return h.Count() == 0
+}
+
+// Clear removes all elements from the queue.
+func (h *concheap[T]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.heap.Clear()
+	wakeAll(&h.pushers)
+}
+
+func (h *concheap[T]) isFull() bool {
+	return h.limit > 0 && h.heap.Count() >= h.limit
+}
+
+// wait adds a waiter to waiters, releases the lock, and waits until the waiter
+// is woken or the context is cancelled. It must be called while holding the lock.
+func (h *concheap[T]) wait(ctx context.Context, waiters *list.List) error {
+	woken := make(chan struct{}, 1)
+	waiter := waiters.PushBack(woken)
+	h.mu.Unlock()
+
+	select {
+	case <-woken:
+		return nil
+	case <-ctx.Done():
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		waiters.Remove(waiter)
+
+		// A wake-up that raced with the cancellation is passed on,
+		// so that it is not lost.
+		select {
+		case <-woken:
+			wakeOne(waiters)
+		default:
+		}
+
+		return ctx.Err()
+	}
+}
+
+// wakeOne wakes the longest-waiting waiter, if any.
+// It must be called while holding the lock.
+func wakeOne(waiters *list.List) {
+	if front := waiters.Front(); front != nil {
+		waiters.Remove(front).(chan struct{}) <- struct{}{}
+	}
+}
+
+// wakeAll wakes all waiters. It must be called while holding the lock.
+func wakeAll(waiters *list.List) {
+	for waiters.Len() > 0 {
+		wakeOne(waiters)
+	}
+}
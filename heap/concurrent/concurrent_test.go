@@ -0,0 +1,304 @@
+package concurrent
+
+import (
+	"container/list"
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+	"github.com/sgago/col/heap"
+	"github.com/stretchr/testify/assert"
+)
+
+var pv1 col.PV[int] = col.PV[int]{Priority: 1, Val: 1}
+var pv2 col.PV[int] = col.PV[int]{Priority: 2, Val: 2}
+var pv3 col.PV[int] = col.PV[int]{Priority: 3, Val: 3}
+
+// awaitWaiters returns once at least n goroutines are parked in waiters.
+func awaitWaiters[T any](h *concheap[T], waiters *list.List, n int) {
+	for {
+		h.mu.Lock()
+		parked := waiters.Len()
+		h.mu.Unlock()
+
+		if parked >= n {
+			return
+		}
+
+		runtime.Gosched()
+	}
+}
+
+// parked returns the number of goroutines parked in waiters.
+func parked[T any](h *concheap[T], waiters *list.List) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return waiters.Len()
+}
+
+func TestNew_WithValues_CountIsCorrect(t *testing.T) {
+	h := New(heap.Min, 3, pv2, pv1)
+
+	assert.Equal(t, 2, h.Count())
+	assert.Zero(t, h.Limit())
+}
+
+func TestPop_WithValues_PopsInOrder(t *testing.T) {
+	h := New(heap.Min, 3, pv3, pv1, pv2)
+	ctx := context.Background()
+
+	for _, expected := range []int{1, 2, 3} {
+		pv, e := h.Pop(ctx)
+
+		assert.Nil(t, e)
+		assert.Equal(t, expected, pv.Priority)
+	}
+}
+
+func TestPop_WithEmptyQueue_WaitsForPush(t *testing.T) {
+	h := New[int](heap.Max, 0)
+	popped := make(chan col.PV[int])
+
+	go func() {
+		pv, _ := h.Pop(context.Background())
+		popped <- pv
+	}()
+
+	awaitWaiters(h, &h.poppers, 1)
+	h.Push(context.Background(), pv2)
+
+	assert.Equal(t, pv2, <-popped)
+}
+
+func TestPop_WithCancelledContext_ReturnsContextError(t *testing.T) {
+	h := New[int](heap.Min, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, e := h.Pop(ctx)
+
+	assert.ErrorIs(t, e, context.DeadlineExceeded)
+}
+
+func TestTryPop_WithEmptyQueue_ReturnsEmptyError(t *testing.T) {
+	h := New[int](heap.Min, 0)
+
+	_, e := h.TryPop()
+
+	assert.IsType(t, &err.Empty{}, e)
+}
+
+func TestTryPop_WithValues_ReturnsTop(t *testing.T) {
+	h := New(heap.Max, 2, pv1, pv2)
+
+	pv, e := h.TryPop()
+
+	assert.Nil(t, e)
+	assert.Equal(t, pv2, pv)
+}
+
+func TestPeek_WithValues_DoesNotRemove(t *testing.T) {
+	h := New(heap.Min, 2, pv2, pv1)
+
+	pv, e := h.Peek()
+
+	assert.Nil(t, e)
+	assert.Equal(t, pv1, pv)
+	assert.Equal(t, 2, h.Count())
+}
+
+func TestPush_WithManyWaitingPops_WakesOnePopPerPush(t *testing.T) {
+	h := New[int](heap.Min, 0)
+	popped := make(chan col.PV[int], 3)
+
+	for i := 0; i < 3; i++ {
+		go func() {
+			pv, _ := h.Pop(context.Background())
+			popped <- pv
+		}()
+	}
+
+	awaitWaiters(h, &h.poppers, 3)
+	h.Push(context.Background(), pv1)
+
+	assert.Equal(t, pv1, <-popped)
+	assert.Equal(t, 2, parked(h, &h.poppers))
+	assert.Empty(t, popped)
+
+	h.Push(context.Background(), pv2)
+	h.Push(context.Background(), pv3)
+
+	assert.ElementsMatch(t, []col.PV[int]{pv2, pv3}, []col.PV[int]{<-popped, <-popped})
+}
+
+func TestPop_WithCancelledWaitingPop_WakesNextPop(t *testing.T) {
+	h := New[int](heap.Min, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	popped := make(chan col.PV[int])
+
+	go func() {
+		_, e := h.Pop(ctx)
+		cancelled <- e
+	}()
+
+	awaitWaiters(h, &h.poppers, 1)
+
+	go func() {
+		pv, _ := h.Pop(context.Background())
+		popped <- pv
+	}()
+
+	awaitWaiters(h, &h.poppers, 2)
+	cancel()
+
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+	assert.Equal(t, 1, parked(h, &h.poppers))
+
+	h.Push(context.Background(), pv1)
+
+	assert.Equal(t, pv1, <-popped)
+}
+
+func TestNewBounded_WithInvalidLimit_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewBounded[int](heap.Min, 0) })
+	assert.Panics(t, func() { NewBounded(heap.Min, 1, pv1, pv2) })
+}
+
+func TestPush_WithFullBoundedQueue_WaitsForPop(t *testing.T) {
+	h := NewBounded(heap.Min, 1, pv1)
+	pushed := make(chan error)
+
+	go func() {
+		pushed <- h.Push(context.Background(), pv2)
+	}()
+
+	awaitWaiters(h, &h.pushers, 1)
+	h.Pop(context.Background())
+
+	assert.Nil(t, <-pushed)
+	assert.Equal(t, 1, h.Count())
+}
+
+func TestPush_WithFullBoundedQueueAndCancelledContext_ReturnsContextError(t *testing.T) {
+	h := NewBounded(heap.Min, 1, pv1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	e := h.Push(ctx, pv2)
+
+	assert.ErrorIs(t, e, context.Canceled)
+}
+
+func TestTryPush_WithFullBoundedQueue_ReturnsFullError(t *testing.T) {
+	h := NewBounded(heap.Min, 1, pv1)
+
+	e := h.TryPush(pv2)
+
+	assert.IsType(t, &err.Full{}, e)
+}
+
+func TestClear_WithWaitingPush_WakesPush(t *testing.T) {
+	h := NewBounded(heap.Min, 1, pv1)
+	pushed := make(chan error)
+
+	go func() {
+		pushed <- h.Push(context.Background(), pv2)
+	}()
+
+	awaitWaiters(h, &h.pushers, 1)
+	h.Clear()
+
+	assert.Nil(t, <-pushed)
+	assert.Equal(t, 1, h.Count())
+}
+
+func TestClose_WithWaitingPop_ReturnsClosedError(t *testing.T) {
+	h := New[int](heap.Min, 0)
+	popped := make(chan error)
+
+	go func() {
+		_, e := h.Pop(context.Background())
+		popped <- e
+	}()
+
+	awaitWaiters(h, &h.poppers, 1)
+	h.Close()
+
+	assert.IsType(t, &err.Closed{}, <-popped)
+	assert.True(t, h.IsClosed())
+}
+
+func TestClose_WithValues_DrainsBeforeClosedError(t *testing.T) {
+	h := New(heap.Min, 2, pv2, pv1)
+
+	h.Close()
+
+	assert.IsType(t, &err.Closed{}, h.Push(context.Background(), pv3))
+
+	pv, e := h.Pop(context.Background())
+	assert.Nil(t, e)
+	assert.Equal(t, pv1, pv)
+
+	pv, e = h.TryPop()
+	assert.Nil(t, e)
+	assert.Equal(t, pv2, pv)
+
+	_, e = h.Pop(context.Background())
+	assert.IsType(t, &err.Closed{}, e)
+}
+
+func TestPushAndPop_WithManyGoroutines_PopsEveryValue(t *testing.T) {
+	h := NewBounded[int](heap.Min, 8)
+	ctx := context.Background()
+
+	var producers sync.WaitGroup
+	var consumers sync.WaitGroup
+	var mu sync.Mutex
+
+	seen := make(map[int]bool)
+
+	for p := 0; p < 4; p++ {
+		producers.Add(1)
+
+		go func(p int) {
+			defer producers.Done()
+
+			for i := 0; i < 250; i++ {
+				v := p*250 + i
+				h.Push(ctx, col.PV[int]{Priority: v, Val: v})
+			}
+		}(p)
+	}
+
+	for c := 0; c < 4; c++ {
+		consumers.Add(1)
+
+		go func() {
+			defer consumers.Done()
+
+			for {
+				pv, e := h.Pop(ctx)
+
+				if e != nil {
+					return
+				}
+
+				mu.Lock()
+				seen[pv.Val] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producers.Wait()
+	h.Close()
+	consumers.Wait()
+
+	assert.Len(t, seen, 1000)
+}
@@ -0,0 +1,225 @@
+// Package delay provides a thread-safe delay queue built on the heap package.
+// A delay queue holds values until their deadlines pass and provides two main operations:
+//
+//  1. Put which adds a value that becomes available at a deadline.
+//  2. Take which removes and returns the value with the earliest passed deadline,
+//     waiting for one if none is available.
+//
+// Values with equal deadlines are taken first-in-first-out.
+// The queue reads time from a Clock, which tests can replace
+// with a fake clock to run deterministically without sleeping.
+package delay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sgago/col/err"
+	"github.com/sgago/col/heap"
+)
+
+// A Clock tells the time and waits for durations to pass.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer starts a timer that sends the current time on the returned channel
+	// once d has passed. The returned stop function stops the timer and returns
+	// true if it stopped the timer before it fired; otherwise, false.
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+}
+
+// The Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	timer := time.NewTimer(d)
+
+	return timer.C, timer.Stop
+}
+
+// A value waiting in the queue until its deadline.
+type item[T any] struct {
+	val      T
+	deadline time.Time
+	seq      uint64
+}
+
+// The heap operations used by the delay queue.
+type timeHeap[T any] interface {
+	Push(val item[T])
+	Pop() item[T]
+	Peek() item[T]
+	Count() int
+	Clear()
+}
+
+// A heap-backed, thread-safe delay queue with type T values.
+type delayqueue[T any] struct {
+	heap  timeHeap[T]
+	clock Clock
+	seq   uint64
+	mu    sync.Mutex
+
+	// changed is closed, and then replaced, whenever a value
+	// is put or the queue is cleared, waking all waiters.
+	changed chan struct{}
+}
+
+// New allocates and initializes a new delay queue with type T values
+// that reads time from the system clock.
+func New[T any]() *delayqueue[T] {
+	return NewWithClock[T](systemClock{})
+}
+
+// NewWithClock allocates and initializes a new delay queue with type T values
+// that reads time from clock.
+//
+// This function panics if clock is nil.
+func NewWithClock[T any](clock Clock) *delayqueue[T] {
+	if clock == nil {
+		panic("The clock cannot be nil.")
+	}
+
+	earlier := func(a item[T], b item[T]) bool {
+		if a.deadline.Equal(b.deadline) {
+			return a.seq < b.seq
+		}
+
+		return a.deadline.Before(b.deadline)
+	}
+
+	return &delayqueue[T]{
+		heap:    heap.NewFunc(earlier, 0),
+		clock:   clock,
+		changed: make(chan struct{}),
+	}
+}
+
+// Put adds a value to the queue that becomes available at deadline.
+func (q *delayqueue[T]) Put(val T, deadline time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	q.heap.Push(item[T]{val: val, deadline: deadline, seq: q.seq})
+	q.broadcast()
+}
+
+// PutAfter adds a value to the queue that becomes available after delay.
+func (q *delayqueue[T]) PutAfter(val T, delay time.Duration) {
+	q.Put(val, q.clock.Now().Add(delay))
+}
+
+// Take removes and returns the value with the earliest deadline.
+// If the queue is empty or the deadline has not passed, Take waits
+// until it does or until an earlier value is put.
+//
+// Take returns an error if the context is cancelled before a value is available.
+func (q *delayqueue[T]) Take(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+
+		var timer <-chan time.Time
+		stop := func() bool { return false }
+
+		if q.heap.Count() > 0 {
+			wait := q.heap.Peek().deadline.Sub(q.clock.Now())
+
+			if wait <= 0 {
+				val := q.heap.Pop().val
+				q.mu.Unlock()
+
+				return val, nil
+			}
+
+			timer, stop = q.clock.NewTimer(wait)
+		}
+
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+			stop()
+		case <-timer:
+		case <-ctx.Done():
+			stop()
+
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// TryTake removes and returns the value with the earliest deadline without waiting.
+//
+// TryTake returns an error if the queue is empty or the deadline has not passed.
+func (q *delayqueue[T]) TryTake() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var zero T
+
+	if q.heap.Count() == 0 {
+		return zero, &err.Empty{}
+	}
+
+	if q.heap.Peek().deadline.After(q.clock.Now()) {
+		return zero, &err.NotReady{}
+	}
+
+	return q.heap.Pop().val, nil
+}
+
+// Peek returns the value with the earliest deadline and its deadline
+// without removing it, whether or not the deadline has passed.
+//
+// Peek returns an error if the queue is empty.
+func (q *delayqueue[T]) Peek() (T, time.Time, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.heap.Count() == 0 {
+		var zero T
+		return zero, time.Time{}, &err.Empty{}
+	}
+
+	top := q.heap.Peek()
+
+	return top.val, top.deadline, nil
+}
+
+// Count returns the number of values in the queue, whether or not they are available.
+func (q *delayqueue[T]) Count() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.heap.Count()
+}
+
+// IsEmpty returns true if the queue has no values;
+// otherwise, false.
+func (q *delayqueue[T]) IsEmpty() bool {
+	return q.Count() == 0
+}
+
+// Clear removes all values from the queue.
+func (q *delayqueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.heap.Clear()
+	q.broadcast()
+}
+
+// broadcast wakes all waiters. It must be called while holding the lock.
+func (q *delayqueue[T]) broadcast() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
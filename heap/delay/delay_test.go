@@ -0,0 +1,288 @@
+package delay
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sgago/col/err"
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// A Clock that only moves when advanced.
+type fakeClock struct {
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{}
+	mu      sync.Mutex
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     start,
+		waiting: make(chan struct{}, 100),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	c.waiting <- struct{}{}
+
+	stop := func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		for i, timer := range c.timers {
+			if timer.ch == ch {
+				c.timers = append(c.timers[:i], c.timers[i+1:]...)
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return ch, stop
+}
+
+// Pending returns the number of timers that have neither fired nor been stopped.
+func (c *fakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+// Advance moves the clock forward by d and fires the timers that are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+		} else {
+			timer.ch <- c.now
+		}
+	}
+
+	c.timers = pending
+}
+
+func TestNewWithClock_WithNilClock_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewWithClock[int](nil) })
+}
+
+func TestTryTake_WithEmptyQueue_ReturnsEmptyError(t *testing.T) {
+	q := NewWithClock[int](newFakeClock())
+
+	_, e := q.TryTake()
+
+	assert.IsType(t, &err.Empty{}, e)
+}
+
+func TestTryTake_BeforeDeadline_ReturnsNotReadyError(t *testing.T) {
+	clock := newFakeClock()
+	q := NewWithClock[int](clock)
+
+	q.PutAfter(1, time.Second)
+
+	_, e := q.TryTake()
+	assert.IsType(t, &err.NotReady{}, e)
+
+	clock.Advance(time.Second)
+
+	val, e := q.TryTake()
+	assert.Nil(t, e)
+	assert.Equal(t, 1, val)
+}
+
+func TestTake_WithPassedDeadlines_TakesInDeadlineOrder(t *testing.T) {
+	clock := newFakeClock()
+	q := NewWithClock[int](clock)
+
+	q.PutAfter(3, 3*time.Second)
+	q.PutAfter(1, time.Second)
+	q.PutAfter(2, 2*time.Second)
+	q.Put(0, start.Add(-time.Second))
+
+	clock.Advance(3 * time.Second)
+
+	for _, expected := range []int{0, 1, 2, 3} {
+		val, e := q.Take(context.Background())
+
+		assert.Nil(t, e)
+		assert.Equal(t, expected, val)
+	}
+}
+
+func TestTake_WithEqualDeadlines_IsFIFO(t *testing.T) {
+	clock := newFakeClock()
+	q := NewWithClock[int](clock)
+
+	for i := 0; i < 20; i++ {
+		q.Put(i, start)
+	}
+
+	for i := 0; i < 20; i++ {
+		val, _ := q.TryTake()
+		assert.Equal(t, i, val)
+	}
+}
+
+func TestTake_BeforeDeadline_WaitsForClock(t *testing.T) {
+	clock := newFakeClock()
+	q := NewWithClock[int](clock)
+	taken := make(chan int)
+
+	q.PutAfter(1, time.Minute)
+
+	go func() {
+		val, _ := q.Take(context.Background())
+		taken <- val
+	}()
+
+	<-clock.waiting
+	clock.Advance(time.Minute)
+
+	assert.Equal(t, 1, <-taken)
+}
+
+func TestTake_WithEarlierPut_TakesEarlierValue(t *testing.T) {
+	clock := newFakeClock()
+	q := NewWithClock[int](clock)
+	taken := make(chan int)
+
+	q.PutAfter(2, time.Hour)
+
+	go func() {
+		val, _ := q.Take(context.Background())
+		taken <- val
+	}()
+
+	<-clock.waiting
+	q.PutAfter(1, time.Second)
+
+	<-clock.waiting
+	clock.Advance(time.Second)
+
+	assert.Equal(t, 1, <-taken)
+	assert.Equal(t, 1, q.Count())
+	assert.Zero(t, clock.Pending())
+}
+
+func TestTake_WithPutsWhileWaiting_StopsEachTimer(t *testing.T) {
+	clock := newFakeClock()
+	q := NewWithClock[int](clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	taken := make(chan error)
+
+	q.PutAfter(0, time.Hour)
+
+	go func() {
+		_, e := q.Take(ctx)
+		taken <- e
+	}()
+
+	<-clock.waiting
+
+	for i := 1; i <= 100; i++ {
+		q.PutAfter(i, 2*time.Hour)
+		<-clock.waiting
+
+		assert.Equal(t, 1, clock.Pending())
+	}
+
+	cancel()
+
+	assert.ErrorIs(t, <-taken, context.Canceled)
+	assert.Zero(t, clock.Pending())
+}
+
+func TestTake_WithEmptyQueue_WaitsForPut(t *testing.T) {
+	clock := newFakeClock()
+	q := NewWithClock[int](clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	taken := make(chan int)
+
+	go func() {
+		val, _ := q.Take(ctx)
+		taken <- val
+	}()
+
+	q.Put(1, start)
+
+	assert.Equal(t, 1, <-taken)
+}
+
+func TestTake_WithCancelledContext_ReturnsContextError(t *testing.T) {
+	q := NewWithClock[int](newFakeClock())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	q.PutAfter(1, time.Second)
+
+	_, e := q.Take(ctx)
+
+	assert.ErrorIs(t, e, context.Canceled)
+}
+
+func TestPeek_WithValues_ReturnsEarliestDeadline(t *testing.T) {
+	q := NewWithClock[int](newFakeClock())
+
+	_, _, e := q.Peek()
+	assert.IsType(t, &err.Empty{}, e)
+
+	q.PutAfter(2, 2*time.Second)
+	q.PutAfter(1, time.Second)
+
+	val, deadline, e := q.Peek()
+
+	assert.Nil(t, e)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, start.Add(time.Second), deadline)
+	assert.Equal(t, 2, q.Count())
+}
+
+func TestClear_WithValues_IsEmpty(t *testing.T) {
+	q := NewWithClock[int](newFakeClock())
+
+	q.PutAfter(1, time.Second)
+	q.Clear()
+
+	assert.True(t, q.IsEmpty())
+}
+
+func TestNew_WithSystemClock_TakesPassedDeadline(t *testing.T) {
+	q := New[int]()
+
+	q.Put(1, time.Now().Add(-time.Second))
+
+	val, e := q.Take(context.Background())
+
+	assert.Nil(t, e)
+	assert.Equal(t, 1, val)
+}
@@ -0,0 +1,193 @@
+package heap
+
+import (
+	"github.com/sgago/col/slice"
+	"golang.org/x/exp/constraints"
+)
+
+// A slice-backed heap data structure with type T values ordered by a less function.
+// The top of the heap is the value that is less than all others.
+type funcheap[T any] struct {
+	elems []T
+	less  func(a T, b T) bool
+}
+
+// NewFunc allocates and initializes a new heap with type T values ordered by less,
+// such as float64 scores, time.Time deadlines or tuple keys.
+// The less function returns true if a belongs above b in the heap.
+//
+// Initial values are heapified bottom-up in O(n) time.
+func NewFunc[T any](less func(a T, b T) bool, cap int, vals ...T) *funcheap[T] {
+	if less == nil {
+		panic("The less function cannot be nil.")
+	}
+
+	h := funcheap[T]{
+		elems: make([]T, 0, cap),
+		less:  less,
+	}
+
+	h.PushMany(vals...)
+
+	return &h
+}
+
+// NewOrdered allocates and initializes a new min or max heap
+// with naturally ordered type T values.
+//
+// Initial values are heapified bottom-up in O(n) time.
+func NewOrdered[T constraints.Ordered](sort HeapSort, cap int, vals ...T) *funcheap[T] {
+	if sort == Min {
+		return NewFunc(func(a T, b T) bool { return a < b }, cap, vals...)
+	}
+
+	return NewFunc(func(a T, b T) bool { return a > b }, cap, vals...)
+}
+
+// Push adds a value to the heap.
+func (h *funcheap[T]) Push(val T) {
+	h.elems = append(h.elems, val)
+
+	h.siftUp(len(h.elems) - 1)
+}
+
+// PushMany adds multiple values to the heap.
+//
+// When the batch is at least as large as the heap, the whole heap is
+// rebuilt bottom-up in O(n) time instead of pushing each value in O(log n) time.
+func (h *funcheap[T]) PushMany(vals ...T) {
+	if len(vals) < len(h.elems) {
+		for _, val := range vals {
+			h.Push(val)
+		}
+
+		return
+	}
+
+	h.elems = append(h.elems, vals...)
+
+	for i := len(h.elems)/2 - 1; i >= 0; i-- {
+		h.siftDown(i)
+	}
+}
+
+// Pop removes and returns the top value of the heap.
+//
+// This method panics if the heap is empty.
+func (h *funcheap[T]) Pop() T {
+	if len(h.elems) == 0 {
+		panic("The heap is empty.")
+	}
+
+	val := h.elems[0]
+	last := len(h.elems) - 1
+
+	h.elems[0] = h.elems[last]
+
+	var zero T
+	h.elems[last] = zero
+	h.elems = slice.RemoveLast(h.elems)
+
+	h.siftDown(0)
+
+	return val
+}
+
+// Peek returns the top value of the heap.
+//
+// This method panics if the heap is empty.
+func (h *funcheap[T]) Peek() T {
+	if len(h.elems) == 0 {
+		panic("The heap is empty.")
+	}
+
+	return h.elems[0]
+}
+
+// RemoveWhere removes all values for which predicate returns true,
+// restores the heap order in O(n) time, and returns the number of values removed.
+func (h *funcheap[T]) RemoveWhere(predicate func(val T) bool) int {
+	kept := 0
+
+	for _, val := range h.elems {
+		if !predicate(val) {
+			h.elems[kept] = val
+			kept++
+		}
+	}
+
+	removed := len(h.elems) - kept
+
+	if removed == 0 {
+		return 0
+	}
+
+	var zero T
+
+	for i := kept; i < len(h.elems); i++ {
+		h.elems[i] = zero
+	}
+
+	h.elems = h.elems[:kept]
+
+	for i := len(h.elems)/2 - 1; i >= 0; i-- {
+		h.siftDown(i)
+	}
+
+	return removed
+}
+
+// Count returns the number of values in the heap.
+func (h *funcheap[T]) Count() int {
+	return len(h.elems)
+}
+
+// Capacity returns the capacity of the heap.
+func (h *funcheap[T]) Capacity() int {
+	return cap(h.elems)
+}
+
+// IsEmpty returns true if the heap has no values;
+// otherwise, false.
+func (h *funcheap[T]) IsEmpty() bool {
+	return h.Count() == 0
+}
+
+// Clear removes all values from the heap.
+// It maintains the heap's existing capacity.
+func (h *funcheap[T]) Clear() {
+	h.elems = slice.Clear(h.elems)
+}
+
+func (h *funcheap[T]) siftUp(index int) {
+	for index > 0 {
+		parent := getParentIndex(index, DefaultArity)
+
+		if !h.less(h.elems[index], h.elems[parent]) {
+			return
+		}
+
+		h.elems = slice.Swap(h.elems, parent, index)
+		index = parent
+	}
+}
+
+func (h *funcheap[T]) siftDown(index int) {
+	for {
+		top := index
+		first := getFirstChildIndex(index, DefaultArity)
+
+		for child := first; child < first+DefaultArity && child < len(h.elems); child++ {
+			if h.less(h.elems[child], h.elems[top]) {
+				top = child
+			}
+		}
+
+		if top == index {
+			return
+		}
+
+		h.elems = slice.Swap(h.elems, top, index)
+		index = top
+	}
+}
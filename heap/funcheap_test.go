@@ -0,0 +1,111 @@
+package heap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFunc_WithNilLess_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewFunc[int](nil, 0) })
+}
+
+func TestNewOrdered_WithMinFloats_PopsInOrder(t *testing.T) {
+	h := NewOrdered(Min, 4, 0.3, 0.1, 0.25, 0.2)
+
+	values := make([]float64, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop())
+	}
+
+	assert.Equal(t, []float64{0.1, 0.2, 0.25, 0.3}, values)
+}
+
+func TestNewOrdered_WithMaxStrings_PopsInOrder(t *testing.T) {
+	h := NewOrdered(Max, 3, "b", "c", "a")
+
+	values := make([]string, 0, 3)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop())
+	}
+
+	assert.Equal(t, []string{"c", "b", "a"}, values)
+}
+
+func TestNewFunc_WithDeadlines_PopsEarliestFirst(t *testing.T) {
+	now := time.Now()
+
+	h := NewFunc(func(a time.Time, b time.Time) bool { return a.Before(b) }, 3)
+
+	h.Push(now.Add(time.Minute))
+	h.Push(now)
+	h.Push(now.Add(time.Second))
+
+	assert.Equal(t, now, h.Pop())
+	assert.Equal(t, now.Add(time.Second), h.Pop())
+	assert.Equal(t, now.Add(time.Minute), h.Pop())
+}
+
+func TestNewFunc_WithTupleKeys_PopsInOrder(t *testing.T) {
+	type key struct {
+		tenant int
+		score  float64
+	}
+
+	h := NewFunc(func(a key, b key) bool {
+		if a.tenant != b.tenant {
+			return a.tenant < b.tenant
+		}
+
+		return a.score > b.score
+	}, 4)
+
+	h.PushMany(key{2, 0.5}, key{1, 0.1}, key{1, 0.9}, key{2, 0.7})
+
+	assert.Equal(t, key{1, 0.9}, h.Pop())
+	assert.Equal(t, key{1, 0.1}, h.Pop())
+	assert.Equal(t, key{2, 0.7}, h.Pop())
+	assert.Equal(t, key{2, 0.5}, h.Pop())
+}
+
+func TestPeek_WithFuncHeap_DoesNotRemove(t *testing.T) {
+	h := NewOrdered(Min, 2, 2, 1)
+
+	assert.Equal(t, 1, h.Peek())
+	assert.Equal(t, 2, h.Count())
+}
+
+func TestPop_WithEmptyFuncHeap_Panics(t *testing.T) {
+	h := NewOrdered[int](Min, 0)
+
+	assert.Panics(t, func() { h.Pop() })
+	assert.Panics(t, func() { h.Peek() })
+}
+
+func TestClear_WithFuncHeap_CapacityIsSame(t *testing.T) {
+	h := NewOrdered(Min, 4, 1, 2)
+
+	h.Clear()
+
+	assert.True(t, h.IsEmpty())
+	assert.Equal(t, 4, h.Capacity())
+}
+
+func TestRemoveWhere_WithFuncHeap_RemovesAndKeepsOrder(t *testing.T) {
+	h := NewOrdered(Max, 0, 5, 2, 8, 1, 9, 4, 7, 3, 6)
+
+	removed := h.RemoveWhere(func(val int) bool { return val%3 == 0 })
+
+	assert.Equal(t, 3, removed)
+
+	values := make([]int, 0, 6)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop())
+	}
+
+	assert.Equal(t, []int{8, 7, 5, 4, 2, 1}, values)
+}
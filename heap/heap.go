@@ -2,6 +2,7 @@ package heap
 
 import (
 	"github.com/sgago/col"
+	"github.com/sgago/col/err"
 	"github.com/sgago/col/slice"
 )
 
@@ -16,51 +17,160 @@ const (
 	Max HeapSort = false
 )
 
+// A Handle refers to an element pushed into a heap with PushHandle or PushManyHandles.
+// It remains valid until the element is popped or removed.
+type Handle struct {
+	index int
+}
+
+// The default number of children of each element in a heap.
+const DefaultArity int = 2
+
+// Options configures a heap created with NewWithOptions.
+// The zero value configures a binary heap.
+type Options struct {
+	// Arity is the number of children of each element, such as 2, 4 or 8.
+	// Higher arities make the heap shallower, which speeds up Push and Update
+	// at the cost of comparing more children in Pop.
+	// Zero uses DefaultArity.
+	Arity int
+
+	// Stable breaks ties between elements with equal priorities by insertion order,
+	// so they are popped first-in-first-out. An element keeps its place in that
+	// order when its priority is updated.
+	Stable bool
+}
+
 type heap[T any] struct {
-	elems      []col.PV[T]
-	bubbleUp   func(index int)
-	bubbleDown func(index int)
+	elems []col.PV[T]
+
+	// handles parallels elems once the first handle is requested,
+	// holding nil for elements pushed without one.
+	handles []*Handle
+
+	// seqs parallels elems in a stable heap,
+	// holding the insertion sequence number of each element.
+	seqs []uint64
+
+	sort   HeapSort
+	arity  int
+	stable bool
+	seq    uint64
 }
 
+// New allocates and initializes a new min or max binary heap with type T values.
+//
+// Initial values are heapified bottom-up in O(n) time.
 func New[T any](sort HeapSort, cap int, pvs ...col.PV[T]) *heap[T] {
-	h := heap[T]{
-		elems: make([]col.PV[T], 0, cap),
+	return NewWithOptions(sort, Options{}, cap, pvs...)
+}
+
+// NewWithOptions allocates and initializes a new min or max heap
+// with type T values, configured by opts.
+//
+// Initial values are heapified bottom-up in O(n) time.
+//
+// This function panics if opts.Arity is negative or one.
+func NewWithOptions[T any](sort HeapSort, opts Options, cap int, pvs ...col.PV[T]) *heap[T] {
+	if opts.Arity == 0 {
+		opts.Arity = DefaultArity
 	}
 
-	if sort == Min {
-		h.bubbleUp = h.bubbleUpMinHeap
-		h.bubbleDown = h.bubbleDownMinHeap
-	} else {
-		h.bubbleUp = h.bubbleUpMaxHeap
-		h.bubbleDown = h.bubbleDownMaxHeap
+	if opts.Arity < 2 {
+		panic("The arity must be at least 2.")
 	}
 
-	for _, pv := range pvs {
-		h.Push(pv)
+	h := heap[T]{
+		elems:  make([]col.PV[T], 0, cap),
+		sort:   sort,
+		arity:  opts.Arity,
+		stable: opts.Stable,
+	}
+
+	if h.stable {
+		h.seqs = make([]uint64, 0, cap)
 	}
 
+	h.PushMany(pvs...)
+
 	return &h
 }
 
+// Push adds an element to the heap.
 func (h *heap[T]) Push(pv col.PV[T]) {
-	h.elems = append(h.elems, pv)
+	h.add(pv, false)
+	h.bubbleUp(len(h.elems) - 1)
+}
 
+// PushHandle adds an element to the heap and returns a handle to it
+// for use with Update and Remove.
+//
+// Handles are tracked only after the first one is requested, so heaps
+// that never use them do not pay for them.
+func (h *heap[T]) PushHandle(pv col.PV[T]) *Handle {
+	handle := h.add(pv, true)
 	h.bubbleUp(len(h.elems) - 1)
+
+	return handle
 }
 
+// PushMany adds multiple elements to the heap.
+//
+// When the batch is at least as large as the heap, the whole heap is
+// rebuilt bottom-up in O(n) time instead of pushing each element in O(log n) time.
+func (h *heap[T]) PushMany(pvs ...col.PV[T]) {
+	h.pushMany(pvs, false)
+}
+
+// PushManyHandles adds multiple elements to the heap like PushMany
+// and returns handles to them, in the same order as the elements.
+func (h *heap[T]) PushManyHandles(pvs ...col.PV[T]) []*Handle {
+	return h.pushMany(pvs, true)
+}
+
+// Pop removes and returns the top element of the heap.
+//
+// This method panics if the heap is empty.
 func (h *heap[T]) Pop() col.PV[T] {
 	if h.elems == nil || len(h.elems) == 0 {
 		panic("The heap is empty.")
 	}
 
-	val := h.elems[0]
+	return h.removeAt(0)
+}
 
-	_, h.elems[0], _ = slice.Last(h.elems, nil)
-	h.elems = slice.RemoveLast(h.elems)
+// Update changes the priority of the element referred to by handle
+// and restores the heap order in O(log n) time.
+// If the element is not in the heap, Update returns an error.
+func (h *heap[T]) Update(handle *Handle, priority int) error {
+	if !h.Contains(handle) {
+		return &err.NotFound{}
+	}
 
-	h.bubbleDown(0)
+	h.elems[handle.index].Priority = priority
 
-	return val
+	h.fix(handle.index)
+
+	return nil
+}
+
+// Remove removes and returns the element referred to by handle in O(log n) time.
+// If the element is not in the heap, Remove returns an error.
+func (h *heap[T]) Remove(handle *Handle) (col.PV[T], error) {
+	if !h.Contains(handle) {
+		return col.PV[T]{}, &err.NotFound{}
+	}
+
+	return h.removeAt(handle.index), nil
+}
+
+// Contains returns true if the element referred to by handle is in the heap;
+// otherwise, false.
+func (h *heap[T]) Contains(handle *Handle) bool {
+	return handle != nil &&
+		handle.index >= 0 &&
+		handle.index < len(h.handles) &&
+		h.handles[handle.index] == handle
 }
 
 func (h *heap[T]) Peek() col.PV[T] {
@@ -71,6 +181,110 @@ func (h *heap[T]) Peek() col.PV[T] {
 	return col.PV[T]{Priority: h.elems[0].Priority, Val: h.elems[0].Val}
 }
 
+// Each calls visit for each element in storage order, which is not sorted.
+// Returning false from visit stops the iteration.
+func (h *heap[T]) Each(visit func(pv col.PV[T]) bool) {
+	for _, pv := range h.elems {
+		if !visit(pv) {
+			return
+		}
+	}
+}
+
+// PopAll removes and returns all elements of the heap in pop order in O(n log n) time.
+func (h *heap[T]) PopAll() []col.PV[T] {
+	pvs := make([]col.PV[T], 0, len(h.elems))
+
+	for !h.IsEmpty() {
+		pvs = append(pvs, h.Pop())
+	}
+
+	return pvs
+}
+
+// Sorted returns all elements of the heap in pop order in O(n log n) time,
+// leaving the heap unchanged.
+func (h *heap[T]) Sorted() []col.PV[T] {
+	clone := heap[T]{
+		elems:  make([]col.PV[T], len(h.elems)),
+		sort:   h.sort,
+		arity:  h.arity,
+		stable: h.stable,
+	}
+
+	copy(clone.elems, h.elems)
+
+	if h.stable {
+		clone.seqs = make([]uint64, len(h.seqs))
+		copy(clone.seqs, h.seqs)
+	}
+
+	return clone.PopAll()
+}
+
+// RemoveWhere removes all elements for which predicate returns true,
+// restores the heap order in O(n) time, and returns the number of elements removed.
+// Handles to removed elements are no longer contained in the heap.
+func (h *heap[T]) RemoveWhere(predicate func(pv col.PV[T]) bool) int {
+	kept := 0
+
+	for i, pv := range h.elems {
+		if predicate(pv) {
+			if h.handles != nil && h.handles[i] != nil {
+				h.handles[i].index = -1
+			}
+
+			continue
+		}
+
+		h.elems[kept] = pv
+
+		if h.handles != nil {
+			h.handles[kept] = h.handles[i]
+
+			if h.handles[kept] != nil {
+				h.handles[kept].index = kept
+			}
+		}
+
+		if h.stable {
+			h.seqs[kept] = h.seqs[i]
+		}
+
+		kept++
+	}
+
+	removed := len(h.elems) - kept
+
+	if removed == 0 {
+		return 0
+	}
+
+	var zero col.PV[T]
+
+	for i := kept; i < len(h.elems); i++ {
+		h.elems[i] = zero
+	}
+
+	h.elems = h.elems[:kept]
+
+	if h.handles != nil {
+		for i := kept; i < len(h.handles); i++ {
+			h.handles[i] = nil
+		}
+
+		h.handles = h.handles[:kept]
+	}
+
+	if h.stable {
+		h.seqs = h.seqs[:kept]
+	}
+
+	h.heapify()
+
+	return removed
+}
+
 // Count returns the number of elements in the heap.
 func (h *heap[T]) Count() int {
 	return len(h.elems)
@@ -90,85 +304,208 @@ func (h *heap[T]) IsEmpty() bool {
 // Clear removes all elements from the heap.
 // It maintains the heap's existing capacity.
 func (h *heap[T]) Clear() {
+	for _, handle := range h.handles {
+		if handle != nil {
+			handle.index = -1
+		}
+	}
+
 	h.elems = slice.Clear(h.elems)
-}
 
-func (h *heap[T]) bubbleUpMinHeap(index int) {
-	parent := getParentIndex(index)
+	if h.handles != nil {
+		h.handles = slice.Clear(h.handles)
+	}
+
+	if h.stable {
+		h.seqs = slice.Clear(h.seqs)
+	}
+}
 
-	if h.elems[parent].Priority > h.elems[index].Priority {
-		h.elems = slice.Swap(h.elems, parent, index)
+// add appends an element, without restoring the heap order,
+// and returns a handle to it if withHandle is true; otherwise, nil.
+func (h *heap[T]) add(pv col.PV[T], withHandle bool) *Handle {
+	var handle *Handle
 
-		if parent > 0 {
-			h.bubbleUpMinHeap(parent)
+	if withHandle {
+		if h.handles == nil {
+			h.handles = make([]*Handle, len(h.elems), cap(h.elems))
 		}
+
+		handle = &Handle{index: len(h.elems)}
+	}
+
+	h.elems = append(h.elems, pv)
+
+	if h.handles != nil {
+		h.handles = append(h.handles, handle)
+	}
+
+	if h.stable {
+		h.seq++
+		h.seqs = append(h.seqs, h.seq)
 	}
+
+	return handle
 }
 
-func (h *heap[T]) bubbleUpMaxHeap(index int) {
-	parent := getParentIndex(index)
+// pushMany adds multiple elements to the heap and, if withHandles is true,
+// returns handles to them; otherwise, nil.
+func (h *heap[T]) pushMany(pvs []col.PV[T], withHandles bool) []*Handle {
+	var handles []*Handle
+
+	if withHandles {
+		handles = make([]*Handle, 0, len(pvs))
+	}
+
+	if len(pvs) < len(h.elems) {
+		for _, pv := range pvs {
+			handle := h.add(pv, withHandles)
+			h.bubbleUp(len(h.elems) - 1)
+
+			if withHandles {
+				handles = append(handles, handle)
+			}
+		}
+
+		return handles
+	}
 
-	if h.elems[parent].Priority < h.elems[index].Priority {
-		h.elems = slice.Swap(h.elems, parent, index)
+	for _, pv := range pvs {
+		handle := h.add(pv, withHandles)
 
-		if parent > 0 {
-			h.bubbleUpMaxHeap(parent)
+		if withHandles {
+			handles = append(handles, handle)
 		}
 	}
+
+	h.heapify()
+
+	return handles
 }
 
-func (h *heap[T]) bubbleDownMinHeap(index int) {
-	if !h.isLeaf(index) {
-		left := getLeftChildIndex(index)
-		right := getRightChildIndex(index)
+// heapify restores the heap order of all elements in O(n) time
+// using Floyd's method, sifting down every parent from the last to the first.
+func (h *heap[T]) heapify() {
+	for i := getParentIndex(len(h.elems)-1, h.arity); i >= 0; i-- {
+		h.bubbleDown(i)
+	}
+}
 
-		smallest := left
-		isRightEmpty := right >= len(h.elems)
+// removeAt removes and returns the element at index,
+// replacing it with the last element and restoring the heap order.
+func (h *heap[T]) removeAt(index int) col.PV[T] {
+	val := h.elems[index]
+	last := len(h.elems) - 1
 
-		if !isRightEmpty && h.elems[right].Priority < h.elems[left].Priority {
-			smallest = right
+	h.swap(index, last)
+	h.elems = slice.RemoveLast(h.elems)
+
+	if h.handles != nil {
+		if h.handles[last] != nil {
+			h.handles[last].index = -1
+			h.handles[last] = nil
 		}
 
-		if h.elems[index].Priority > h.elems[smallest].Priority {
-			h.elems = slice.Swap(h.elems, smallest, index)
+		h.handles = slice.RemoveLast(h.handles)
+	}
 
-			h.bubbleDownMinHeap(smallest)
-		}
+	if h.stable {
+		h.seqs = slice.RemoveLast(h.seqs)
 	}
+
+	if index < last {
+		h.fix(index)
+	}
+
+	return val
 }
 
-func (h *heap[T]) bubbleDownMaxHeap(index int) {
-	if !h.isLeaf(index) {
-		left := getLeftChildIndex(index)
-		right := getRightChildIndex(index)
+// fix restores the heap order after the element at index changes.
+// An element that moves up cannot also need to move down.
+func (h *heap[T]) fix(index int) {
+	if h.bubbleUp(index) == index {
+		h.bubbleDown(index)
+	}
+}
 
-		largest := left
-		isRightEmpty := right >= len(h.elems)
+// bubbleUp moves the element at index up until it is in heap order
+// and returns its new index.
+func (h *heap[T]) bubbleUp(index int) int {
+	for index > 0 {
+		parent := getParentIndex(index, h.arity)
 
-		if !isRightEmpty && h.elems[left].Priority > h.elems[right].Priority {
-			largest = left
+		if !h.above(index, parent) {
+			break
 		}
 
-		if h.elems[index].Priority < h.elems[largest].Priority {
-			h.elems = slice.Swap(h.elems, largest, index)
+		h.swap(parent, index)
+		index = parent
+	}
+
+	return index
+}
+
+func (h *heap[T]) bubbleDown(index int) {
+	for {
+		top := index
+		first := getFirstChildIndex(index, h.arity)
 
-			h.bubbleDownMaxHeap(largest)
+		for child := first; child < first+h.arity && child < len(h.elems); child++ {
+			if h.above(child, top) {
+				top = child
+			}
 		}
+
+		if top == index {
+			return
+		}
+
+		h.swap(top, index)
+		index = top
 	}
 }
 
-func (h *heap[T]) isLeaf(index int) bool {
-	return len(h.elems) <= getLeftChildIndex(index)
+// above returns true if the element at indexA belongs above
+// the element at indexB in the heap order.
+// In a stable heap, the earlier pushed of two equal elements belongs above.
+func (h *heap[T]) above(indexA int, indexB int) bool {
+	if h.stable && h.elems[indexA].Priority == h.elems[indexB].Priority {
+		return h.seqs[indexA] < h.seqs[indexB]
+	}
+
+	if h.sort == Min {
+		return h.elems[indexA].Priority < h.elems[indexB].Priority
+	}
+
+	return h.elems[indexA].Priority > h.elems[indexB].Priority
 }
 
-func getParentIndex(index int) int {
-	return (index - 1) / 2
+// swap exchanges the elements at indexA and indexB
+// and their handles and sequence numbers, if tracked.
+func (h *heap[T]) swap(indexA int, indexB int) {
+	h.elems = slice.Swap(h.elems, indexA, indexB)
+
+	if h.handles != nil {
+		h.handles = slice.Swap(h.handles, indexA, indexB)
+
+		if h.handles[indexA] != nil {
+			h.handles[indexA].index = indexA
+		}
+
+		if h.handles[indexB] != nil {
+			h.handles[indexB].index = indexB
+		}
+	}
+
+	if h.stable {
+		h.seqs = slice.Swap(h.seqs, indexA, indexB)
+	}
 }
 
-func getLeftChildIndex(index int) int {
-	return 2*index + 1
+func getParentIndex(index int, arity int) int {
+	return (index - 1) / arity
 }
 
-func getRightChildIndex(index int) int {
-	return 2*index + 2
+func getFirstChildIndex(index int, arity int) int {
+	return arity*index + 1
 }
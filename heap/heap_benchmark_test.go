@@ -0,0 +1,92 @@
+package heap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/sgago/col"
+)
+
+var benchmarkArities = []int{2, 4, 8}
+
+const benchmarkSize = 100_000
+
+func benchmarkPriorities() []int {
+	r := rand.New(rand.NewSource(1))
+	priorities := make([]int, benchmarkSize)
+
+	for i := range priorities {
+		priorities[i] = r.Int()
+	}
+
+	return priorities
+}
+
+func BenchmarkPush(b *testing.B) {
+	priorities := benchmarkPriorities()
+
+	for _, arity := range benchmarkArities {
+		b.Run(fmt.Sprintf("Arity%d", arity), func(b *testing.B) {
+			h := NewWithOptions[int](Min, Options{Arity: arity}, benchmarkSize)
+
+			for i := 0; i < b.N; i++ {
+				if h.Count() == benchmarkSize {
+					h.Clear()
+				}
+
+				h.Push(col.PV[int]{Priority: priorities[i%benchmarkSize]})
+			}
+		})
+	}
+}
+
+func BenchmarkPop(b *testing.B) {
+	priorities := benchmarkPriorities()
+
+	for _, arity := range benchmarkArities {
+		b.Run(fmt.Sprintf("Arity%d", arity), func(b *testing.B) {
+			h := NewWithOptions[int](Min, Options{Arity: arity}, benchmarkSize)
+
+			for i := 0; i < b.N; i++ {
+				if h.IsEmpty() {
+					b.StopTimer()
+
+					for _, p := range priorities {
+						h.Push(col.PV[int]{Priority: p})
+					}
+
+					b.StartTimer()
+				}
+
+				h.Pop()
+			}
+		})
+	}
+}
+
+func BenchmarkUpdate(b *testing.B) {
+	priorities := benchmarkPriorities()
+
+	for _, arity := range benchmarkArities {
+		b.Run(fmt.Sprintf("Arity%d", arity), func(b *testing.B) {
+			h := NewWithOptions[int](Min, Options{Arity: arity}, benchmarkSize)
+			handles := make([]*Handle, 0, benchmarkSize)
+
+			for _, p := range priorities {
+				handles = append(handles, h.PushHandle(col.PV[int]{Priority: p}))
+			}
+
+			// Priorities are non-negative, so each new one is a new minimum
+			// and every update moves an element up, as in Dijkstra's and Prim's algorithms.
+			next := 0
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				next--
+				h.Update(handles[i%benchmarkSize], next)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertHeapOrder asserts that no element is above its parent in the heap order.
+func assertHeapOrder[T any](t *testing.T, h *heap[T], sort HeapSort) bool {
+	for i := 1; i < len(h.elems); i++ {
+		parent := h.elems[getParentIndex(i, h.arity)].Priority
+		child := h.elems[i].Priority
+
+		if (sort == Min && parent > child) || (sort == Max && parent < child) {
+			return assert.Failf(t, "heap order violated", "parent %d, child %d at index %d", parent, child, i)
+		}
+	}
+
+	return true
+}
+
+func TestPushAndPop_WithRandomPriorities_KeepsHeapOrder(t *testing.T) {
+	for _, arity := range []int{2, 4, 8} {
+		for _, sort := range []HeapSort{Min, Max} {
+			if !pushAndPopRandomly(t, NewWithOptions[int](sort, Options{Arity: arity}, 0), sort) {
+				return
+			}
+		}
+	}
+}
+
+// pushAndPopRandomly checks the heap order after every Push and Pop.
+func pushAndPopRandomly(t *testing.T, h *heap[int], sort HeapSort) bool {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 5000; i++ {
+		if h.IsEmpty() || r.Intn(3) != 0 {
+			h.Push(col.PV[int]{Priority: r.Intn(100), Val: i})
+		} else {
+			top := h.Peek().Priority
+			popped := h.Pop().Priority
+
+			assert.Equal(t, top, popped)
+		}
+
+		if !assertHeapOrder(t, h, sort) {
+			return false
+		}
+	}
+
+	for !h.IsEmpty() {
+		popped := h.Pop().Priority
+
+		if !h.IsEmpty() {
+			next := h.Peek().Priority
+			assert.True(t, (sort == Min && popped <= next) || (sort == Max && popped >= next))
+		}
+
+		if !assertHeapOrder(t, h, sort) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNew_WithRandomPriorities_KeepsHeapOrder(t *testing.T) {
+	for _, sort := range []HeapSort{Min, Max} {
+		r := rand.New(rand.NewSource(2))
+		pvs := make([]col.PV[int], 0, 1000)
+
+		for i := 0; i < 1000; i++ {
+			pvs = append(pvs, col.PV[int]{Priority: r.Intn(1000), Val: i})
+		}
+
+		h := New(sort, 0, pvs...)
+
+		assertHeapOrder(t, h, sort)
+	}
+}
+
+func TestUpdateAndRemove_WithRandomHandles_KeepsHeapOrder(t *testing.T) {
+	for _, sort := range []HeapSort{Min, Max} {
+		r := rand.New(rand.NewSource(3))
+		h := New[int](sort, 0)
+		handles := make([]*Handle, 0)
+
+		for i := 0; i < 500; i++ {
+			handles = append(handles, h.PushHandle(col.PV[int]{Priority: r.Intn(100), Val: i}))
+		}
+
+		for i := 0; i < 2000; i++ {
+			handle := handles[r.Intn(len(handles))]
+
+			if r.Intn(4) == 0 {
+				h.Remove(handle)
+			} else {
+				h.Update(handle, r.Intn(100))
+			}
+
+			if !assertHeapOrder(t, h, sort) {
+				return
+			}
+		}
+	}
+}
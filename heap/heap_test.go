@@ -150,3 +150,466 @@ func TestClear_WithElements_CapacityIsSame(t *testing.T) {
 
 	assert.Equal(t, cap, h.Capacity())
 }
+
+func TestASCII_WithMinHeap_RendersSideways(t *testing.T) {
+	h := New(Min, 4, pv1, pv2, pv3, pv4)
+
+	expected := "" +
+		"/-- 3: 3\n" +
+		"1: 1\n" +
+		"\\-- 2: 2\n" +
+		"    \\-- 4: 4\n"
+
+	assert.Equal(t, expected, h.ASCII())
+}
+
+func TestASCII_WithEmptyHeap_IsEmpty(t *testing.T) {
+	h := New[int](Min, 0)
+
+	assert.Empty(t, h.ASCII())
+}
+
+func TestDOT_WithMinHeap_RendersDigraph(t *testing.T) {
+	h := New(Min, 3, pv1, pv2, pv3)
+
+	expected := "digraph {\n" +
+		"\tn0 [label=\"1: 1\"];\n" +
+		"\tn1 [label=\"2: 2\"];\n" +
+		"\tn2 [label=\"3: 3\"];\n" +
+		"\tn0 -> n1;\n" +
+		"\tn0 -> n2;\n" +
+		"}\n"
+
+	assert.Equal(t, expected, h.DOT())
+}
+
+func TestPush_WithValue_HandleIsContained(t *testing.T) {
+	h := New[int](Min, 4)
+
+	handle := h.PushHandle(pv1)
+
+	assert.True(t, h.Contains(handle))
+}
+
+func TestPop_WithHandle_HandleIsNotContained(t *testing.T) {
+	h := New[int](Min, 4)
+
+	handle := h.PushHandle(pv1)
+	h.Push(pv2)
+
+	h.Pop()
+
+	assert.False(t, h.Contains(handle))
+}
+
+func TestContains_WithHandleFromOtherHeap_IsFalse(t *testing.T) {
+	a := New[int](Min, 1)
+	b := New[int](Min, 1)
+
+	handle := a.PushHandle(pv1)
+	b.PushHandle(pv1)
+
+	assert.False(t, b.Contains(handle))
+	assert.False(t, b.Contains(nil))
+}
+
+func TestUpdate_WithDecreasedPriority_MovesToTop(t *testing.T) {
+	h := New[int](Min, 4)
+
+	h.Push(pv1)
+	h.Push(pv2)
+	h.Push(pv3)
+	handle := h.PushHandle(pv4)
+
+	e := h.Update(handle, 0)
+
+	assert.Nil(t, e)
+	assert.Equal(t, 4, h.Peek().Val)
+	assert.Equal(t, 0, h.Peek().Priority)
+}
+
+func TestUpdate_WithIncreasedPriority_MovesDown(t *testing.T) {
+	h := New[int](Min, 4)
+
+	handle := h.PushHandle(pv1)
+	h.Push(pv2)
+	h.Push(pv3)
+	h.Push(pv4)
+
+	h.Update(handle, 5)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Val)
+	}
+
+	assert.Equal(t, []int{2, 3, 4, 1}, values)
+}
+
+func TestUpdate_WithRemovedHandle_ReturnsError(t *testing.T) {
+	h := New[int](Min, 4)
+
+	handle := h.PushHandle(pv1)
+	h.Pop()
+
+	assert.NotNil(t, h.Update(handle, 5))
+}
+
+func TestRemove_WithHandle_ElementIsRemoved(t *testing.T) {
+	h := New[int](Min, 4)
+
+	h.Push(pv1)
+	handle := h.PushHandle(pv2)
+	h.Push(pv3)
+	h.Push(pv4)
+
+	pv, e := h.Remove(handle)
+
+	assert.Nil(t, e)
+	assert.Equal(t, pv2, pv)
+	assert.Equal(t, 3, h.Count())
+	assert.False(t, h.Contains(handle))
+
+	values := make([]int, 0, 3)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{1, 3, 4}, values)
+}
+
+func TestRemove_WithRemovedHandle_ReturnsError(t *testing.T) {
+	h := New[int](Min, 4)
+
+	handle := h.PushHandle(pv1)
+	h.Remove(handle)
+
+	_, e := h.Remove(handle)
+
+	assert.NotNil(t, e)
+}
+
+func TestClear_WithHandles_HandlesAreNotContained(t *testing.T) {
+	h := New[int](Min, 4)
+
+	handle := h.PushHandle(pv1)
+	h.Clear()
+	h.Push(pv2)
+
+	assert.False(t, h.Contains(handle))
+}
+
+func TestNew_WithValues_PopsInOrder(t *testing.T) {
+	h := New(Min, 8, pv4, pv2, pv3, pv1, pv2)
+
+	values := make([]int, 0, 5)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{1, 2, 2, 3, 4}, values)
+}
+
+func TestPushMany_WithSmallBatch_PopsInOrder(t *testing.T) {
+	h := New(Min, 8, pv4, pv3, pv1)
+
+	h.PushMany(pv2)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+}
+
+func TestPushMany_WithLargeBatch_PopsInOrder(t *testing.T) {
+	h := New(Min, 8, pv3)
+
+	h.PushMany(pv4, pv2, pv1)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+}
+
+func TestPushMany_WithValues_HandlesMatchValues(t *testing.T) {
+	h := New[int](Min, 8)
+
+	handles := h.PushManyHandles(pv4, pv2, pv3, pv1)
+
+	for i, handle := range handles {
+		pv, e := h.Remove(handle)
+
+		assert.Nil(t, e)
+		assert.Equal(t, []int{4, 2, 3, 1}[i], pv.Val)
+	}
+}
+
+func TestNew_WithMaxHeapValues_PopsInOrder(t *testing.T) {
+	h := New(Max, 8, pv1, pv2, pv3, pv2, pv4)
+
+	values := make([]int, 0, 5)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{4, 3, 2, 2, 1}, values)
+}
+
+func TestPushMany_WithMaxHeapSmallBatch_PopsInOrder(t *testing.T) {
+	h := New(Max, 8, pv1, pv2, pv4)
+
+	h.PushMany(pv3)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{4, 3, 2, 1}, values)
+}
+
+func TestPushMany_WithMaxHeapLargeBatch_PopsInOrder(t *testing.T) {
+	h := New(Max, 8, pv2)
+
+	h.PushMany(pv1, pv3, pv4)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{4, 3, 2, 1}, values)
+}
+
+func TestNewWithOptions_WithInvalidArity_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewWithOptions[int](Min, Options{Arity: 1}, 0) })
+	assert.Panics(t, func() { NewWithOptions[int](Min, Options{Arity: -2}, 0) })
+}
+
+func TestNewWithOptions_WithZeroArity_IsBinary(t *testing.T) {
+	h := NewWithOptions[int](Min, Options{}, 0)
+
+	assert.Equal(t, DefaultArity, h.arity)
+}
+
+func TestPop_WithArities_ElementsCorrect(t *testing.T) {
+	for _, arity := range []int{2, 3, 4, 8} {
+		for _, sort := range []HeapSort{Min, Max} {
+			h := NewWithOptions[int](sort, Options{Arity: arity}, 0)
+
+			for _, p := range []int{5, 3, 9, 1, 7, 2, 8, 6, 4, 0} {
+				h.Push(col.PV[int]{Priority: p, Val: p})
+			}
+
+			values := make([]int, 0, 10)
+
+			for !h.IsEmpty() {
+				values = append(values, h.Pop().Priority)
+			}
+
+			if sort == Min {
+				assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, values)
+			} else {
+				assert.Equal(t, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, values)
+			}
+		}
+	}
+}
+
+func TestASCII_WithQuaternaryHeap_RendersSideways(t *testing.T) {
+	h := NewWithOptions(Min, Options{Arity: 4}, 5, pv1, pv2, pv3, pv4)
+
+	expected := "" +
+		"/-- 4: 4\n" +
+		"1: 1\n" +
+		"|-- 3: 3\n" +
+		"\\-- 2: 2\n"
+
+	assert.Equal(t, expected, h.ASCII())
+}
+
+func TestPop_WithStableHeap_EqualPrioritiesAreFIFO(t *testing.T) {
+	for _, arity := range []int{2, 4} {
+		for _, sort := range []HeapSort{Min, Max} {
+			h := NewWithOptions[int](sort, Options{Arity: arity, Stable: true}, 0)
+
+			for i := 0; i < 100; i++ {
+				h.Push(col.PV[int]{Priority: i % 3, Val: i})
+			}
+
+			previous := h.Pop()
+
+			for !h.IsEmpty() {
+				pv := h.Pop()
+
+				if pv.Priority == previous.Priority {
+					assert.Less(t, previous.Val, pv.Val)
+				}
+
+				previous = pv
+			}
+		}
+	}
+}
+
+func TestPushMany_WithStableHeap_EqualPrioritiesAreFIFO(t *testing.T) {
+	pvs := make([]col.PV[int], 0, 50)
+
+	for i := 0; i < 50; i++ {
+		pvs = append(pvs, col.PV[int]{Priority: 1, Val: i})
+	}
+
+	h := NewWithOptions(Min, Options{Stable: true}, 0, pvs...)
+
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, i, h.Pop().Val)
+	}
+}
+
+func TestUpdate_WithStableHeap_KeepsInsertionOrder(t *testing.T) {
+	h := NewWithOptions[int](Min, Options{Stable: true}, 0)
+
+	first := h.PushHandle(col.PV[int]{Priority: 5, Val: 1})
+	h.Push(col.PV[int]{Priority: 2, Val: 2})
+
+	h.Update(first, 2)
+
+	assert.Equal(t, 1, h.Pop().Val)
+	assert.Equal(t, 2, h.Pop().Val)
+}
+
+func TestEach_WithValues_VisitsStorageOrder(t *testing.T) {
+	h := New(Min, 4, pv3, pv1, pv2)
+	visited := make([]col.PV[int], 0, 3)
+
+	h.Each(func(pv col.PV[int]) bool {
+		visited = append(visited, pv)
+		return true
+	})
+
+	assert.Equal(t, h.elems, visited)
+}
+
+func TestEach_WithVisitReturningFalse_Stops(t *testing.T) {
+	h := New(Min, 4, pv3, pv1, pv2)
+	count := 0
+
+	h.Each(func(pv col.PV[int]) bool {
+		count++
+		return false
+	})
+
+	assert.Equal(t, 1, count)
+}
+
+func TestPopAll_WithValues_ReturnsSortedAndEmpties(t *testing.T) {
+	h := New(Max, 4, pv2, pv4, pv1, pv3)
+
+	assert.Equal(t, []col.PV[int]{pv4, pv3, pv2, pv1}, h.PopAll())
+	assert.True(t, h.IsEmpty())
+}
+
+func TestSorted_WithValues_LeavesHeapUnchanged(t *testing.T) {
+	h := New(Min, 4, pv2, pv4, pv1, pv3)
+	pv0 := col.PV[int]{Priority: 0, Val: 0}
+	handle := h.PushHandle(pv0)
+	elems := append([]col.PV[int]{}, h.elems...)
+
+	assert.Equal(t, []col.PV[int]{pv0, pv1, pv2, pv3, pv4}, h.Sorted())
+	assert.Equal(t, elems, h.elems)
+	assert.True(t, h.Contains(handle))
+	assert.Equal(t, 0, handle.index)
+}
+
+func TestSorted_WithStableHeap_EqualPrioritiesAreFIFO(t *testing.T) {
+	h := NewWithOptions[int](Min, Options{Stable: true}, 0)
+
+	for i := 0; i < 20; i++ {
+		h.Push(col.PV[int]{Priority: 1, Val: i})
+	}
+
+	for i, pv := range h.Sorted() {
+		assert.Equal(t, i, pv.Val)
+	}
+}
+
+func TestRemoveWhere_WithMatches_RemovesAndKeepsOrder(t *testing.T) {
+	h := New[int](Min, 0)
+	handles := make([]*Handle, 0, 20)
+
+	for i := 19; i >= 0; i-- {
+		handles = append(handles, h.PushHandle(col.PV[int]{Priority: i, Val: i}))
+	}
+
+	removed := h.RemoveWhere(func(pv col.PV[int]) bool { return pv.Val%2 == 0 })
+
+	assert.Equal(t, 10, removed)
+	assert.Equal(t, 10, h.Count())
+
+	for _, handle := range handles {
+		if handle.index >= 0 {
+			assert.True(t, h.Contains(handle))
+			assert.Equal(t, 1, h.elems[handle.index].Val%2)
+		} else {
+			assert.False(t, h.Contains(handle))
+		}
+	}
+
+	assert.Equal(t, []col.PV[int]{{Priority: 1, Val: 1}, {Priority: 3, Val: 3}}, h.PopAll()[:2])
+}
+
+func TestRemoveWhere_WithNoMatches_ReturnsZero(t *testing.T) {
+	h := New(Min, 4, pv1, pv2)
+
+	assert.Zero(t, h.RemoveWhere(func(pv col.PV[int]) bool { return false }))
+	assert.Equal(t, 2, h.Count())
+}
+
+func TestPush_WithoutHandles_DoesNotTrackHandles(t *testing.T) {
+	h := New(Min, 4, pv3, pv1)
+
+	h.Push(pv2)
+	h.PushMany(pv4)
+	h.Pop()
+
+	assert.Nil(t, h.handles)
+}
+
+func TestPushHandle_AfterPushes_TracksOnlyHandledElements(t *testing.T) {
+	h := New(Min, 0, pv2, pv3, pv4)
+
+	h.Push(col.PV[int]{Priority: 5, Val: 5})
+	handle := h.PushHandle(pv1)
+	h.Push(col.PV[int]{Priority: 0, Val: 0})
+
+	assert.Len(t, h.handles, h.Count())
+	assert.Equal(t, pv1, h.elems[handle.index])
+
+	assert.Nil(t, h.Update(handle, 9))
+
+	assert.Equal(t, []int{0, 2, 3, 4, 5, 9}, priorities(h.PopAll()))
+	assert.False(t, h.Contains(handle))
+}
+
+func priorities(pvs []col.PV[int]) []int {
+	result := make([]int, 0, len(pvs))
+
+	for _, pv := range pvs {
+		result = append(result, pv.Priority)
+	}
+
+	return result
+}
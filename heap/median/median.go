@@ -0,0 +1,262 @@
+// Package median provides a streaming median and quantile tracker
+// built on two heaps from the heap package.
+// The lower half of the values is kept in a max heap and the upper half
+// in a min heap, balanced so that the tracked quantile lies on their tops.
+//
+// Values can be removed again, for example as they leave a sliding window.
+// Removed values are deleted lazily: they are popped once they reach
+// the top of their heap, and a heap is rebuilt without them once they
+// outnumber its live values. The heaps therefore hold at most about twice
+// the live values, and Add and Remove take amortized O(log n) time
+// in the number of live values.
+package median
+
+import (
+	"math"
+
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+	"github.com/sgago/col/heap"
+)
+
+// The heap operations used by the tracker.
+type valueHeap[T any] interface {
+	Push(val T)
+	Pop() T
+	Peek() T
+	Count() int
+	RemoveWhere(predicate func(val T) bool) int
+	Clear()
+}
+
+// One half of the tracked values, with the values removed from it
+// that are still waiting to be popped.
+type half[T col.Number] struct {
+	heap    valueHeap[T]
+	removed map[T]int
+	dead    int
+	count   int
+}
+
+// A two-heap streaming quantile tracker with type T values.
+type tracker[T col.Number] struct {
+	lower    half[T]
+	upper    half[T]
+	quantile float64
+	counts   map[T]int
+}
+
+// New allocates and initializes a new streaming median tracker with type T values.
+func New[T col.Number]() *tracker[T] {
+	return NewQuantile[T](0.5)
+}
+
+// NewQuantile allocates and initializes a new streaming tracker with type T values
+// for the quantile q, such as 0.5 for the median or 0.99 for the 99th percentile.
+//
+// This function panics if q is not between 0 and 1.
+func NewQuantile[T col.Number](q float64) *tracker[T] {
+	if !(q >= 0 && q <= 1) {
+		panic("The quantile must be between 0 and 1.")
+	}
+
+	return &tracker[T]{
+		lower:    half[T]{heap: heap.NewOrdered[T](heap.Max, 0), removed: make(map[T]int)},
+		upper:    half[T]{heap: heap.NewOrdered[T](heap.Min, 0), removed: make(map[T]int)},
+		quantile: q,
+		counts:   make(map[T]int),
+	}
+}
+
+// Add adds a value to the tracker in O(log n) time.
+func (t *tracker[T]) Add(val T) {
+	if t.lower.count == 0 || val <= t.lower.top() {
+		t.lower.push(val)
+	} else {
+		t.upper.push(val)
+	}
+
+	t.counts[val]++
+	t.balance()
+}
+
+// Remove removes one occurrence of a value from the tracker in amortized O(log n) time.
+// If the value is not in the tracker, Remove returns an error.
+func (t *tracker[T]) Remove(val T) error {
+	if t.counts[val] == 0 {
+		return &err.NotFound{}
+	}
+
+	// The top of the lower half is live, so a value no greater than it
+	// has an occurrence in the lower half, and a greater value does not.
+	if val <= t.lower.top() {
+		t.lower.remove(val)
+	} else {
+		t.upper.remove(val)
+	}
+
+	t.counts[val]--
+
+	if t.counts[val] == 0 {
+		delete(t.counts, val)
+	}
+
+	t.balance()
+
+	return nil
+}
+
+// Quantile returns the tracked quantile of the values in O(1) time,
+// interpolating linearly between the two closest values.
+//
+// This method panics if the tracker is empty.
+func (t *tracker[T]) Quantile() float64 {
+	if t.IsEmpty() {
+		panic("The tracker is empty.")
+	}
+
+	_, fraction := t.rank()
+	low := float64(t.lower.top())
+
+	if fraction == 0 {
+		return low
+	}
+
+	return low + fraction*(float64(t.upper.top())-low)
+}
+
+// Median returns the tracked quantile of the values in O(1) time,
+// which is the median for a tracker allocated with New.
+// For an even number of values, the median is the mean of the two middle values.
+//
+// This method panics if the tracker is empty.
+func (t *tracker[T]) Median() float64 {
+	return t.Quantile()
+}
+
+// Count returns the number of values in the tracker.
+func (t *tracker[T]) Count() int {
+	return t.lower.count + t.upper.count
+}
+
+// IsEmpty returns true if the tracker has no values;
+// otherwise, false.
+func (t *tracker[T]) IsEmpty() bool {
+	return t.Count() == 0
+}
+
+// Clear removes all values from the tracker.
+func (t *tracker[T]) Clear() {
+	t.lower.clear()
+	t.upper.clear()
+	t.counts = make(map[T]int)
+}
+
+// rank returns the zero-based rank of the value on top of the lower half
+// and the fraction of the way to the next rank at which the quantile lies.
+func (t *tracker[T]) rank() (int, float64) {
+	position := t.quantile * float64(t.Count()-1)
+	rank := math.Floor(position)
+
+	return int(rank), position - rank
+}
+
+// balance moves values between the halves until the lower half
+// holds the values up to and including the quantile's rank.
+func (t *tracker[T]) balance() {
+	target := 0
+
+	if !t.IsEmpty() {
+		rank, _ := t.rank()
+		target = rank + 1
+	}
+
+	for t.lower.count > target {
+		t.upper.push(t.lower.pop())
+	}
+
+	for t.lower.count < target {
+		t.lower.push(t.upper.pop())
+	}
+}
+
+func (h *half[T]) push(val T) {
+	h.heap.Push(val)
+	h.count++
+}
+
+// pop removes and returns the live value on top of the half.
+func (h *half[T]) pop() T {
+	val := h.top()
+
+	h.heap.Pop()
+	h.count--
+	h.prune()
+
+	return val
+}
+
+// top returns the live value on top of the half.
+func (h *half[T]) top() T {
+	h.prune()
+
+	return h.heap.Peek()
+}
+
+// remove marks an occurrence of val as removed from the half,
+// popping it right away if it is on top, and compacts the half
+// once removed values outnumber live ones.
+func (h *half[T]) remove(val T) {
+	h.removed[val]++
+	h.dead++
+	h.count--
+	h.prune()
+
+	if h.dead > h.count {
+		h.compact()
+	}
+}
+
+// prune pops removed values from the top of the half.
+func (h *half[T]) prune() {
+	for h.heap.Count() > 0 {
+		top := h.heap.Peek()
+
+		if h.removed[top] == 0 {
+			return
+		}
+
+		h.heap.Pop()
+		h.unmark(top)
+	}
+}
+
+// compact rebuilds the half without its removed values in O(n) time.
+func (h *half[T]) compact() {
+	h.heap.RemoveWhere(func(val T) bool {
+		if h.removed[val] == 0 {
+			return false
+		}
+
+		h.unmark(val)
+
+		return true
+	})
+}
+
+// unmark forgets one removed occurrence of val after it is deleted from the heap.
+func (h *half[T]) unmark(val T) {
+	h.removed[val]--
+	h.dead--
+
+	if h.removed[val] == 0 {
+		delete(h.removed, val)
+	}
+}
+
+func (h *half[T]) clear() {
+	h.heap.Clear()
+	h.removed = make(map[T]int)
+	h.dead = 0
+	h.count = 0
+}
@@ -0,0 +1,152 @@
+package median
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/sgago/col/err"
+	"github.com/stretchr/testify/assert"
+)
+
+// quantile returns the quantile q of vals, interpolating linearly.
+func quantile(vals []int, q float64) float64 {
+	sorted := make([]int, len(vals))
+	copy(sorted, vals)
+	sort.Ints(sorted)
+
+	position := q * float64(len(sorted)-1)
+	rank := int(math.Floor(position))
+	low := float64(sorted[rank])
+
+	if rank+1 == len(sorted) {
+		return low
+	}
+
+	return low + (position-float64(rank))*(float64(sorted[rank+1])-low)
+}
+
+func TestMedian_WithOddCount_ReturnsMiddleValue(t *testing.T) {
+	m := New[int]()
+
+	for _, val := range []int{5, 1, 9} {
+		m.Add(val)
+	}
+
+	assert.Equal(t, 5.0, m.Median())
+}
+
+func TestMedian_WithEvenCount_ReturnsMeanOfMiddleValues(t *testing.T) {
+	m := New[int]()
+
+	for _, val := range []int{5, 1, 9, 2} {
+		m.Add(val)
+	}
+
+	assert.Equal(t, 3.5, m.Median())
+}
+
+func TestMedian_WithFloats_ReturnsMedian(t *testing.T) {
+	m := New[float64]()
+
+	m.Add(0.5)
+	m.Add(1.5)
+
+	assert.Equal(t, 1.0, m.Median())
+}
+
+func TestMedian_WithEmptyTracker_Panics(t *testing.T) {
+	m := New[int]()
+
+	assert.Panics(t, func() { m.Median() })
+}
+
+func TestRemove_WithMissingValue_ReturnsError(t *testing.T) {
+	m := New[int]()
+	m.Add(1)
+
+	assert.IsType(t, &err.NotFound{}, m.Remove(2))
+	assert.Equal(t, 1, m.Count())
+}
+
+func TestRemove_WithValues_UpdatesMedian(t *testing.T) {
+	m := New[int]()
+
+	for _, val := range []int{1, 2, 3, 4, 5} {
+		m.Add(val)
+	}
+
+	assert.Nil(t, m.Remove(3))
+	assert.Equal(t, 3.0, m.Median())
+
+	assert.Nil(t, m.Remove(1))
+	assert.Equal(t, 4.0, m.Median())
+}
+
+func TestNewQuantile_WithInvalidQuantile_Panics(t *testing.T) {
+	assert.Panics(t, func() { NewQuantile[int](-0.1) })
+	assert.Panics(t, func() { NewQuantile[int](1.1) })
+	assert.Panics(t, func() { NewQuantile[int](math.NaN()) })
+}
+
+func TestQuantile_WithSlidingWindow_MatchesSortedReference(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, q := range []float64{0, 0.1, 0.5, 0.9, 0.99, 1} {
+		m := NewQuantile[int](q)
+		window := make([]int, 0)
+
+		for i := 0; i < 2000; i++ {
+			val := r.Intn(50)
+			m.Add(val)
+			window = append(window, val)
+
+			if len(window) > 25 {
+				assert.Nil(t, m.Remove(window[0]))
+				window = window[1:]
+			}
+
+			if !assert.InDelta(t, quantile(window, q), m.Quantile(), 1e-9) {
+				return
+			}
+		}
+
+		assert.Equal(t, 25, m.Count())
+	}
+}
+
+func TestClear_WithValues_IsEmpty(t *testing.T) {
+	m := New[int]()
+	m.Add(1)
+	m.Add(2)
+	m.Remove(1)
+
+	m.Clear()
+
+	assert.True(t, m.IsEmpty())
+
+	m.Add(3)
+	assert.Equal(t, 3.0, m.Median())
+}
+
+func TestRemove_WithSlidingWindowOverIncreasingStream_HeapsStayBounded(t *testing.T) {
+	m := New[int]()
+	window := 100
+
+	for i := 0; i < 100000; i++ {
+		m.Add(i)
+
+		if i >= window {
+			assert.Nil(t, m.Remove(i-window))
+		}
+
+		if size := m.lower.heap.Count() + m.upper.heap.Count(); size > 2*window+2 {
+			assert.Failf(t, "The heaps grew past the window.", "size %d after %d values", size, i+1)
+			return
+		}
+	}
+
+	assert.Equal(t, window, m.Count())
+	assert.Equal(t, 99949.5, m.Median())
+}
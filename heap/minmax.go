@@ -0,0 +1,220 @@
+package heap
+
+import (
+	"math/bits"
+
+	"github.com/sgago/col"
+	"github.com/sgago/col/slice"
+)
+
+// A slice-backed, double-ended min-max heap data structure with type T values.
+// Elements on even levels of the implicit binary tree are no greater than
+// their descendants, and elements on odd levels are no less than their descendants,
+// so both the minimum and the maximum are available in O(1) time.
+type minmaxheap[T any] struct {
+	elems []col.PV[T]
+}
+
+// NewMinMax allocates and initializes a new min-max heap with type T values.
+//
+// Initial values are heapified bottom-up in O(n) time.
+func NewMinMax[T any](cap int, pvs ...col.PV[T]) *minmaxheap[T] {
+	h := minmaxheap[T]{
+		elems: make([]col.PV[T], 0, cap),
+	}
+
+	h.elems = append(h.elems, pvs...)
+
+	for i := len(h.elems)/2 - 1; i >= 0; i-- {
+		h.trickleDown(i)
+	}
+
+	return &h
+}
+
+// Push adds an element to the heap in O(log n) time.
+func (h *minmaxheap[T]) Push(pv col.PV[T]) {
+	h.elems = append(h.elems, pv)
+
+	h.bubbleUp(len(h.elems) - 1)
+}
+
+// PeekMin returns the element with the lowest priority.
+//
+// This method panics if the heap is empty.
+func (h *minmaxheap[T]) PeekMin() col.PV[T] {
+	if h.IsEmpty() {
+		panic("The heap is empty.")
+	}
+
+	return h.elems[0]
+}
+
+// PeekMax returns the element with the highest priority.
+//
+// This method panics if the heap is empty.
+func (h *minmaxheap[T]) PeekMax() col.PV[T] {
+	if h.IsEmpty() {
+		panic("The heap is empty.")
+	}
+
+	return h.elems[h.maxIndex()]
+}
+
+// PopMin removes and returns the element with the lowest priority in O(log n) time.
+//
+// This method panics if the heap is empty.
+func (h *minmaxheap[T]) PopMin() col.PV[T] {
+	if h.IsEmpty() {
+		panic("The heap is empty.")
+	}
+
+	return h.removeAt(0)
+}
+
+// PopMax removes and returns the element with the highest priority in O(log n) time.
+//
+// This method panics if the heap is empty.
+func (h *minmaxheap[T]) PopMax() col.PV[T] {
+	if h.IsEmpty() {
+		panic("The heap is empty.")
+	}
+
+	return h.removeAt(h.maxIndex())
+}
+
+// Count returns the number of elements in the heap.
+func (h *minmaxheap[T]) Count() int {
+	return len(h.elems)
+}
+
+// Capacity returns the capacity of the heap.
+func (h *minmaxheap[T]) Capacity() int {
+	return cap(h.elems)
+}
+
+// IsEmpty returns true if the heap has no elements;
+// otherwise, false.
+func (h *minmaxheap[T]) IsEmpty() bool {
+	return h.Count() == 0
+}
+
+// Clear removes all elements from the heap.
+// It maintains the heap's existing capacity.
+func (h *minmaxheap[T]) Clear() {
+	h.elems = slice.Clear(h.elems)
+}
+
+// maxIndex returns the index of the element with the highest priority,
+// which is the root or one of its children.
+func (h *minmaxheap[T]) maxIndex() int {
+	switch {
+	case len(h.elems) == 1:
+		return 0
+	case len(h.elems) == 2 || h.elems[1].Priority >= h.elems[2].Priority:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (h *minmaxheap[T]) removeAt(index int) col.PV[T] {
+	pv := h.elems[index]
+	last := len(h.elems) - 1
+
+	h.elems = slice.Swap(h.elems, index, last)
+	h.elems = slice.RemoveLast(h.elems)
+
+	if index < len(h.elems) {
+		h.trickleDown(index)
+	}
+
+	return pv
+}
+
+// isMinLevel returns true if index is on an even level of the tree.
+func isMinLevel(index int) bool {
+	return (bits.Len(uint(index+1))-1)%2 == 0
+}
+
+// above returns true if the element at i belongs above the element at j
+// on a min level, or on a max level if minLevel is false.
+func (h *minmaxheap[T]) above(i int, j int, minLevel bool) bool {
+	if minLevel {
+		return h.elems[i].Priority < h.elems[j].Priority
+	}
+
+	return h.elems[i].Priority > h.elems[j].Priority
+}
+
+func (h *minmaxheap[T]) bubbleUp(index int) {
+	if index == 0 {
+		return
+	}
+
+	minLevel := isMinLevel(index)
+	parent := getParentIndex(index, DefaultArity)
+
+	// An element that belongs on the other kind of level
+	// swaps with its parent and continues up those levels.
+	if h.above(parent, index, minLevel) {
+		h.elems = slice.Swap(h.elems, parent, index)
+		index = parent
+		minLevel = !minLevel
+	}
+
+	for index > 2 {
+		grandparent := getParentIndex(getParentIndex(index, DefaultArity), DefaultArity)
+
+		if !h.above(index, grandparent, minLevel) {
+			return
+		}
+
+		h.elems = slice.Swap(h.elems, grandparent, index)
+		index = grandparent
+	}
+}
+
+func (h *minmaxheap[T]) trickleDown(index int) {
+	minLevel := isMinLevel(index)
+
+	for {
+		top := index
+		child := getFirstChildIndex(index, DefaultArity)
+
+		// Find the top of the children and grandchildren.
+		for c := child; c < child+DefaultArity && c < len(h.elems); c++ {
+			if h.above(c, top, minLevel) {
+				top = c
+			}
+
+			grandchild := getFirstChildIndex(c, DefaultArity)
+
+			for g := grandchild; g < grandchild+DefaultArity && g < len(h.elems); g++ {
+				if h.above(g, top, minLevel) {
+					top = g
+				}
+			}
+		}
+
+		if top == index {
+			return
+		}
+
+		h.elems = slice.Swap(h.elems, top, index)
+
+		if getParentIndex(top, DefaultArity) == index {
+			return
+		}
+
+		// The element moved down two levels, so it may belong
+		// above its new parent on the other kind of level.
+		parent := getParentIndex(top, DefaultArity)
+
+		if h.above(parent, top, minLevel) {
+			h.elems = slice.Swap(h.elems, parent, top)
+		}
+
+		index = top
+	}
+}
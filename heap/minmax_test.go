@@ -0,0 +1,117 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMinMax_WithValues_PeeksCorrect(t *testing.T) {
+	h := NewMinMax(4, pv3, pv1, pv4, pv2)
+
+	assert.Equal(t, 4, h.Count())
+	assert.Equal(t, pv1, h.PeekMin())
+	assert.Equal(t, pv4, h.PeekMax())
+}
+
+func TestPeek_WithOneElementMinMaxHeap_MinAndMaxAreEqual(t *testing.T) {
+	h := NewMinMax(1, pv2)
+
+	assert.Equal(t, pv2, h.PeekMin())
+	assert.Equal(t, pv2, h.PeekMax())
+}
+
+func TestPop_WithEmptyMinMaxHeap_Panics(t *testing.T) {
+	h := NewMinMax[int](0)
+
+	assert.Panics(t, func() { h.PeekMin() })
+	assert.Panics(t, func() { h.PeekMax() })
+	assert.Panics(t, func() { h.PopMin() })
+	assert.Panics(t, func() { h.PopMax() })
+}
+
+func TestPopMin_WithMinMaxHeap_ElementsCorrect(t *testing.T) {
+	h := NewMinMax[int](4)
+
+	h.Push(pv3)
+	h.Push(pv1)
+	h.Push(pv4)
+	h.Push(pv2)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.PopMin().Priority)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+}
+
+func TestPopMax_WithMinMaxHeap_ElementsCorrect(t *testing.T) {
+	h := NewMinMax(4, pv3, pv1, pv4, pv2)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.PopMax().Priority)
+	}
+
+	assert.Equal(t, []int{4, 3, 2, 1}, values)
+}
+
+func TestPopMinAndPopMax_WithRandomOperations_MatchSortedReference(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	initial := make([]col.PV[int], 0, 50)
+	reference := make([]int, 0, 50)
+
+	for i := 0; i < 50; i++ {
+		priority := r.Intn(100)
+		initial = append(initial, col.PV[int]{Priority: priority, Val: i})
+		reference = append(reference, priority)
+	}
+
+	h := NewMinMax(0, initial...)
+
+	for i := 0; i < 5000; i++ {
+		switch r.Intn(3) {
+		case 0:
+			priority := r.Intn(100)
+			h.Push(col.PV[int]{Priority: priority})
+			reference = append(reference, priority)
+		case 1:
+			if len(reference) > 0 {
+				assert.Equal(t, reference[0], h.PopMin().Priority)
+				reference = reference[1:]
+			}
+		case 2:
+			if len(reference) > 0 {
+				assert.Equal(t, reference[len(reference)-1], h.PopMax().Priority)
+				reference = reference[:len(reference)-1]
+			}
+		}
+
+		sort.Ints(reference)
+
+		if !assert.Equal(t, len(reference), h.Count()) {
+			return
+		}
+
+		if len(reference) > 0 {
+			assert.Equal(t, reference[0], h.PeekMin().Priority)
+			assert.Equal(t, reference[len(reference)-1], h.PeekMax().Priority)
+		}
+	}
+}
+
+func TestClear_WithMinMaxHeap_IsEmptyAndCapacityRemains(t *testing.T) {
+	h := NewMinMax(4, pv1, pv2)
+
+	h.Clear()
+
+	assert.True(t, h.IsEmpty())
+	assert.Equal(t, 4, h.Capacity())
+}
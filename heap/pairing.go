@@ -0,0 +1,145 @@
+package heap
+
+import "github.com/sgago/col"
+
+// A pointer-based, meldable pairing heap data structure with type T values.
+// Push and Merge take O(1) time, and Pop takes amortized O(log n) time.
+type pairingheap[T any] struct {
+	root  *pairingnode[T]
+	count int
+	sort  HeapSort
+}
+
+// Each node points to its first child and to its next sibling.
+type pairingnode[T any] struct {
+	col.PV[T]
+	child   *pairingnode[T]
+	sibling *pairingnode[T]
+}
+
+// NewPairing allocates and initializes a new min or max pairing heap with type T values.
+func NewPairing[T any](sort HeapSort, pvs ...col.PV[T]) *pairingheap[T] {
+	h := pairingheap[T]{sort: sort}
+
+	for _, pv := range pvs {
+		h.Push(pv)
+	}
+
+	return &h
+}
+
+// Push adds an element to the heap in O(1) time.
+func (h *pairingheap[T]) Push(pv col.PV[T]) {
+	h.root = h.meld(h.root, &pairingnode[T]{PV: pv})
+	h.count++
+}
+
+// Pop removes and returns the top element of the heap in amortized O(log n) time.
+//
+// This method panics if the heap is empty.
+func (h *pairingheap[T]) Pop() col.PV[T] {
+	if h.root == nil {
+		panic("The heap is empty.")
+	}
+
+	pv := h.root.PV
+
+	h.root = h.mergePairs(h.root.child)
+	h.count--
+
+	return pv
+}
+
+// Peek returns the top element of the heap.
+//
+// This method panics if the heap is empty.
+func (h *pairingheap[T]) Peek() col.PV[T] {
+	if h.root == nil {
+		panic("The heap is empty.")
+	}
+
+	return h.root.PV
+}
+
+// Merge moves all elements from other into the heap in O(1) time, leaving other empty.
+//
+// This method panics if the heaps have different sort orders.
+func (h *pairingheap[T]) Merge(other *pairingheap[T]) {
+	if h.sort != other.sort {
+		panic("The heaps have different sort orders.")
+	}
+
+	if h == other {
+		return
+	}
+
+	h.root = h.meld(h.root, other.root)
+	h.count += other.count
+
+	other.Clear()
+}
+
+// Count returns the number of elements in the heap.
+func (h *pairingheap[T]) Count() int {
+	return h.count
+}
+
+// IsEmpty returns true if the heap has no elements;
+// otherwise, false.
+func (h *pairingheap[T]) IsEmpty() bool {
+	return h.Count() == 0
+}
+
+// Clear removes all elements from the heap.
+func (h *pairingheap[T]) Clear() {
+	h.root = nil
+	h.count = 0
+}
+
+// meld joins two heaps by making the root that belongs lower
+// the first child of the other root.
+func (h *pairingheap[T]) meld(a *pairingnode[T], b *pairingnode[T]) *pairingnode[T] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if (h.sort == Min && b.Priority < a.Priority) || (h.sort == Max && b.Priority > a.Priority) {
+		a, b = b, a
+	}
+
+	b.sibling = a.child
+	a.child = b
+
+	return a
+}
+
+// mergePairs melds a list of siblings in two passes: first in pairs
+// from left to right, and then the pairs from right to left.
+func (h *pairingheap[T]) mergePairs(first *pairingnode[T]) *pairingnode[T] {
+	pairs := make([]*pairingnode[T], 0)
+
+	for first != nil {
+		a, b := first, first.sibling
+		first = nil
+
+		if b != nil {
+			first = b.sibling
+			b.sibling = nil
+		}
+
+		a.sibling = nil
+		pairs = append(pairs, h.meld(a, b))
+	}
+
+	var root *pairingnode[T]
+
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.meld(pairs[i], root)
+	}
+
+	return root
+}
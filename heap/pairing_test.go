@@ -0,0 +1,118 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPairing_WithValues_CountIsCorrect(t *testing.T) {
+	h := NewPairing(Min, pv3, pv1, pv2)
+
+	assert.Equal(t, 3, h.Count())
+	assert.Equal(t, pv1, h.Peek())
+}
+
+func TestPop_WithPairingMinHeap_ElementsCorrect(t *testing.T) {
+	h := NewPairing(Min, pv3, pv2, pv1, pv4)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+}
+
+func TestPop_WithPairingMaxHeap_ElementsCorrect(t *testing.T) {
+	h := NewPairing(Max, pv3, pv2, pv1, pv4)
+
+	values := make([]int, 0, 4)
+
+	for !h.IsEmpty() {
+		values = append(values, h.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{4, 3, 2, 1}, values)
+}
+
+func TestPop_WithEmptyPairingHeap_Panics(t *testing.T) {
+	h := NewPairing[int](Min)
+
+	assert.Panics(t, func() { h.Pop() })
+	assert.Panics(t, func() { h.Peek() })
+}
+
+func TestMerge_WithPairingHeaps_ContainsAllElements(t *testing.T) {
+	a := NewPairing(Min, pv3, pv1)
+	b := NewPairing(Min, pv4, pv2)
+
+	a.Merge(b)
+
+	assert.Equal(t, 4, a.Count())
+	assert.True(t, b.IsEmpty())
+
+	values := make([]int, 0, 4)
+
+	for !a.IsEmpty() {
+		values = append(values, a.Pop().Priority)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+}
+
+func TestMerge_WithDifferentSortOrders_Panics(t *testing.T) {
+	a := NewPairing(Min, pv1)
+	b := NewPairing(Max, pv2)
+
+	assert.Panics(t, func() { a.Merge(b) })
+}
+
+func TestMerge_WithSelf_DoesNothing(t *testing.T) {
+	a := NewPairing(Min, pv1, pv2)
+
+	a.Merge(a)
+
+	assert.Equal(t, 2, a.Count())
+}
+
+func TestMerge_WithRandomPartitions_PopsInOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	merged := NewPairing[int](Max)
+	expected := make([]int, 0)
+
+	for p := 0; p < 10; p++ {
+		partition := NewPairing[int](Max)
+
+		for i := 0; i < 100; i++ {
+			priority := r.Intn(1000)
+			partition.Push(col.PV[int]{Priority: priority, Val: i})
+			expected = append(expected, priority)
+		}
+
+		partition.Pop()
+		merged.Merge(partition)
+	}
+
+	assert.Equal(t, 990, merged.Count())
+
+	actual := make([]int, 0, merged.Count())
+
+	for !merged.IsEmpty() {
+		actual = append(actual, merged.Pop().Priority)
+	}
+
+	assert.True(t, sort.SliceIsSorted(actual, func(i, j int) bool { return actual[i] > actual[j] }))
+}
+
+func TestClear_WithPairingHeap_IsEmpty(t *testing.T) {
+	h := NewPairing(Min, pv1, pv2)
+
+	h.Clear()
+
+	assert.True(t, h.IsEmpty())
+}
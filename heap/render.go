@@ -0,0 +1,91 @@
+package heap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ASCII returns the shape of the heap's implicit tree as sideways ASCII art,
+// one element per line, with the first half of each element's children
+// below it and the rest above it. Each element is shown as priority: value.
+//
+// For example, a min binary heap built from priorities 1, 2, and 3 renders as:
+//
+//	/-- 3: 3
+//	1: 1
+//	\-- 2: 2
+func (h *heap[T]) ASCII() string {
+	var b strings.Builder
+
+	if !h.IsEmpty() {
+		h.writeASCII(&b, 0, "", "", "", "")
+	}
+
+	return b.String()
+}
+
+// DOT returns the shape of the heap's implicit tree as a Graphviz DOT digraph.
+// Each node is named after its index in the heap's storage and
+// labelled as priority: value.
+func (h *heap[T]) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph {\n")
+
+	for i := range h.elems {
+		fmt.Fprintf(&b, "\tn%d [label=%q];\n", i, h.label(i))
+	}
+
+	for i := 1; i < len(h.elems); i++ {
+		fmt.Fprintf(&b, "\tn%d -> n%d;\n", getParentIndex(i, h.arity), i)
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+func (h *heap[T]) label(index int) string {
+	return fmt.Sprintf("%d: %v", h.elems[index].Priority, h.elems[index].Val)
+}
+
+// writeASCII writes the subtree rooted at index. The prefix is written before
+// every line, the edge before the element's own line, and the above and below
+// strings extend the prefix for the children above and below the element, respectively.
+func (h *heap[T]) writeASCII(b *strings.Builder, index int, prefix string, edge string, above string, below string) {
+	first := getFirstChildIndex(index, h.arity)
+	end := first + h.arity
+
+	if end > len(h.elems) {
+		end = len(h.elems)
+	}
+
+	if first > end {
+		first = end
+	}
+
+	// Children are drawn last to first, from the top down,
+	// with the first half, rounded up, below the element.
+	mid := first + (end-first+1)/2
+
+	for child := end - 1; child >= mid; child-- {
+		if child == end-1 {
+			h.writeASCII(b, child, prefix+above, "/-- ", "    ", "|   ")
+		} else {
+			h.writeASCII(b, child, prefix+above, "|-- ", "|   ", "|   ")
+		}
+	}
+
+	b.WriteString(prefix)
+	b.WriteString(edge)
+	b.WriteString(h.label(index))
+	b.WriteString("\n")
+
+	for child := mid - 1; child >= first; child-- {
+		if child == first {
+			h.writeASCII(b, child, prefix+below, "\\-- ", "|   ", "    ")
+		} else {
+			h.writeASCII(b, child, prefix+below, "|-- ", "|   ", "|   ")
+		}
+	}
+}
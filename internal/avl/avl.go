@@ -0,0 +1,140 @@
+// Package avl provides the height bookkeeping, rotations and rebalancing
+// shared by the AVL-balanced trees in this module.
+//
+// The functions work on any node type whose pointer implements Node,
+// so each tree keeps its own node fields, such as keys or interval ends,
+// and recalculates them alongside the height in Update.
+package avl
+
+// A Node is a pointer to an AVL tree node of type N.
+type Node[N any] interface {
+	comparable
+
+	// Left returns the node's left child.
+	Left() N
+
+	// SetLeft replaces the node's left child.
+	SetLeft(left N)
+
+	// Right returns the node's right child.
+	Right() N
+
+	// SetRight replaces the node's right child.
+	SetRight(right N)
+
+	// Height returns the node's stored height.
+	// It is only called on non-nil nodes.
+	Height() int
+
+	// Update recalculates the node's height, usually with Measure,
+	// and any other fields derived from its children.
+	Update()
+}
+
+// Height returns the height of the subtree rooted at n,
+// where an empty subtree has height 0.
+func Height[N Node[N]](n N) int {
+	var none N
+
+	if n == none {
+		return 0
+	}
+
+	return n.Height()
+}
+
+// Measure returns the height of n calculated from the heights of its children.
+func Measure[N Node[N]](n N) int {
+	left, right := Height(n.Left()), Height(n.Right())
+
+	if left > right {
+		return left + 1
+	}
+
+	return right + 1
+}
+
+// Rebalance updates n and rotates the subtree rooted at n
+// until its children's heights differ by at most one.
+// It returns the new root of the subtree.
+func Rebalance[N Node[N]](n N) N {
+	n.Update()
+
+	switch balance := Height(n.Left()) - Height(n.Right()); {
+	case balance > 1:
+		if Height(n.Left().Left()) < Height(n.Left().Right()) {
+			n.SetLeft(rotateLeft(n.Left()))
+		}
+
+		return rotateRight(n)
+	case balance < -1:
+		if Height(n.Right().Right()) < Height(n.Right().Left()) {
+			n.SetRight(rotateRight(n.Right()))
+		}
+
+		return rotateLeft(n)
+	}
+
+	return n
+}
+
+// RemoveMin removes the leftmost node of the subtree rooted at n and
+// returns the new, rebalanced root of the subtree and the removed node.
+func RemoveMin[N Node[N]](n N) (N, N) {
+	var none N
+
+	if n.Left() == none {
+		return n.Right(), n
+	}
+
+	left, min := RemoveMin(n.Left())
+	n.SetLeft(left)
+
+	return Rebalance(n), min
+}
+
+// RemoveRoot removes n from the subtree it roots, replacing it with its
+// in-order successor if it has two children, and returns the new,
+// rebalanced root of the subtree.
+func RemoveRoot[N Node[N]](n N) N {
+	var none N
+
+	if n.Left() == none {
+		return n.Right()
+	}
+
+	if n.Right() == none {
+		return n.Left()
+	}
+
+	right, successor := RemoveMin(n.Right())
+
+	successor.SetLeft(n.Left())
+	successor.SetRight(right)
+
+	return Rebalance(successor)
+}
+
+func rotateLeft[N Node[N]](n N) N {
+	r := n.Right()
+
+	n.SetRight(r.Left())
+	r.SetLeft(n)
+
+	n.Update()
+	r.Update()
+
+	return r
+}
+
+func rotateRight[N Node[N]](n N) N {
+	l := n.Left()
+
+	n.SetLeft(l.Right())
+	l.SetRight(n)
+
+	n.Update()
+	l.Update()
+
+	return l
+}
@@ -0,0 +1,123 @@
+package avl
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type node struct {
+	key    int
+	height int
+	left   *node
+	right  *node
+}
+
+func (n *node) Left() *node          { return n.left }
+func (n *node) SetLeft(left *node)   { n.left = left }
+func (n *node) Right() *node         { return n.right }
+func (n *node) SetRight(right *node) { n.right = right }
+func (n *node) Height() int          { return n.height }
+func (n *node) Update()              { n.height = Measure(n) }
+
+func insert(n *node, key int) *node {
+	if n == nil {
+		return &node{key: key, height: 1}
+	}
+
+	if key < n.key {
+		n.left = insert(n.left, key)
+	} else {
+		n.right = insert(n.right, key)
+	}
+
+	return Rebalance(n)
+}
+
+func remove(n *node, key int) *node {
+	switch {
+	case n == nil:
+		return nil
+	case key < n.key:
+		n.left = remove(n.left, key)
+	case key > n.key:
+		n.right = remove(n.right, key)
+	default:
+		return RemoveRoot(n)
+	}
+
+	return Rebalance(n)
+}
+
+// assertBalanced checks the order, heights and balance of the subtree
+// rooted at n and returns its keys in order.
+func assertBalanced(t *testing.T, n *node, keys []int) []int {
+	if n == nil {
+		return keys
+	}
+
+	keys = assertBalanced(t, n.left, keys)
+
+	if len(keys) > 0 {
+		assert.LessOrEqual(t, keys[len(keys)-1], n.key)
+	}
+
+	keys = assertBalanced(t, n.right, append(keys, n.key))
+
+	balance := Height(n.left) - Height(n.right)
+
+	assert.Equal(t, Measure(n), n.height)
+	assert.True(t, balance >= -1 && balance <= 1)
+
+	return keys
+}
+
+func TestHeight_WithNilNode_IsZero(t *testing.T) {
+	assert.Zero(t, Height[*node](nil))
+}
+
+func TestRebalance_WithAscendingInserts_StaysBalanced(t *testing.T) {
+	var root *node
+
+	for i := 0; i < 1023; i++ {
+		root = insert(root, i)
+	}
+
+	assert.Len(t, assertBalanced(t, root, nil), 1023)
+	assert.Equal(t, 10, Height(root))
+}
+
+func TestRemoveRoot_WithRandomRemoves_StaysBalanced(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	keys := r.Perm(500)
+
+	var root *node
+
+	for _, key := range keys {
+		root = insert(root, key)
+	}
+
+	for i, key := range keys[:400] {
+		root = remove(root, key)
+
+		if i%50 == 0 {
+			assertBalanced(t, root, nil)
+		}
+	}
+
+	assert.Len(t, assertBalanced(t, root, nil), 100)
+}
+
+func TestRemoveMin_WithTree_RemovesLeftmost(t *testing.T) {
+	var root *node
+
+	for _, key := range []int{5, 3, 8, 1, 4} {
+		root = insert(root, key)
+	}
+
+	root, min := RemoveMin(root)
+
+	assert.Equal(t, 1, min.key)
+	assert.Equal(t, []int{3, 4, 5, 8}, assertBalanced(t, root, nil))
+}
@@ -0,0 +1,226 @@
+// Package intervaltree provides an AVL-balanced interval tree data structure.
+// Intervals are half-open, [Lo, Hi), keyed by their start, and each node is
+// augmented with the maximum end found in its subtree.
+// An interval tree provides three main operations:
+//
+//  1. Insert which adds an interval to the tree.
+//  2. Remove which removes an interval from the tree.
+//  3. QueryPoint and QueryRange which find all intervals overlapping a point or a range.
+//
+// This interval tree data structure is NOT thread-safe.
+package intervaltree
+
+import (
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+	"github.com/sgago/col/internal/avl"
+)
+
+// A half-open interval, [Lo, Hi), with a type T value.
+type Interval[T any] struct {
+	Lo  int
+	Hi  int
+	Val T
+}
+
+// A node's Priority is the start of its interval.
+type node[T any] struct {
+	col.PV[T]
+	hi     int
+	max    int
+	height int
+	left   *node[T]
+	right  *node[T]
+}
+
+// An AVL-balanced interval tree data structure with type T values.
+type intervaltree[T any] struct {
+	root  *node[T]
+	count int
+}
+
+// New allocates and initializes a new interval tree with type T values.
+//
+// This function panics if any interval is empty, that is, Hi <= Lo.
+func New[T any](intervals ...Interval[T]) *intervaltree[T] {
+	t := intervaltree[T]{}
+
+	for _, i := range intervals {
+		t.Insert(i.Lo, i.Hi, i.Val)
+	}
+
+	return &t
+}
+
+// Insert adds the interval [lo, hi) with value val to the tree.
+//
+// This method panics if the interval is empty, that is, hi <= lo.
+func (t *intervaltree[T]) Insert(lo int, hi int, val T) {
+	if hi <= lo {
+		panic("The interval is empty.")
+	}
+
+	t.root = insert(t.root, &node[T]{
+		PV:     col.PV[T]{Priority: lo, Val: val},
+		hi:     hi,
+		max:    hi,
+		height: 1,
+	})
+
+	t.count++
+}
+
+// Remove removes one interval exactly matching [lo, hi) from the tree.
+// If no such interval exists, Remove returns an error.
+func (t *intervaltree[T]) Remove(lo int, hi int) error {
+	root, removed := remove(t.root, lo, hi)
+
+	if !removed {
+		return &err.NotFound{}
+	}
+
+	t.root = root
+	t.count--
+
+	return nil
+}
+
+// QueryPoint returns all intervals that contain point, ordered by their start.
+func (t *intervaltree[T]) QueryPoint(point int) []Interval[T] {
+	return t.QueryRange(point, point+1)
+}
+
+// QueryRange returns all intervals that overlap [lo, hi), ordered by their start.
+// Empty ranges, where hi <= lo, overlap nothing.
+func (t *intervaltree[T]) QueryRange(lo int, hi int) []Interval[T] {
+	result := make([]Interval[T], 0)
+
+	if hi <= lo {
+		return result
+	}
+
+	return query(t.root, lo, hi, result)
+}
+
+// Count returns the number of intervals in the tree.
+func (t *intervaltree[T]) Count() int {
+	return t.count
+}
+
+// IsEmpty returns true if the tree has no intervals;
+// otherwise, false.
+func (t *intervaltree[T]) IsEmpty() bool {
+	return t.Count() == 0
+}
+
+// Clear removes all intervals from the tree.
+func (t *intervaltree[T]) Clear() {
+	t.root = nil
+	t.count = 0
+}
+
+func query[T any](n *node[T], lo int, hi int, result []Interval[T]) []Interval[T] {
+	if n == nil || n.max <= lo {
+		return result
+	}
+
+	result = query(n.left, lo, hi, result)
+
+	// Everything to the right starts at or after n, so it cannot overlap either.
+	if n.Priority >= hi {
+		return result
+	}
+
+	if lo < n.hi {
+		result = append(result, Interval[T]{Lo: n.Priority, Hi: n.hi, Val: n.Val})
+	}
+
+	return query(n.right, lo, hi, result)
+}
+
+// compare orders intervals by start, then by end.
+func compare[T any](n *node[T], lo int, hi int) int {
+	switch {
+	case lo < n.Priority:
+		return -1
+	case lo > n.Priority:
+		return 1
+	case hi < n.hi:
+		return -1
+	case hi > n.hi:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func insert[T any](n *node[T], in *node[T]) *node[T] {
+	if n == nil {
+		return in
+	}
+
+	if compare(n, in.Priority, in.hi) < 0 {
+		n.left = insert(n.left, in)
+	} else {
+		n.right = insert(n.right, in)
+	}
+
+	return avl.Rebalance(n)
+}
+
+func remove[T any](n *node[T], lo int, hi int) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+
+	switch c := compare(n, lo, hi); {
+	case c < 0:
+		n.left, removed = remove(n.left, lo, hi)
+	case c > 0:
+		n.right, removed = remove(n.right, lo, hi)
+	default:
+		return avl.RemoveRoot(n), true
+	}
+
+	if !removed {
+		return n, false
+	}
+
+	return avl.Rebalance(n), true
+}
+
+func (n *node[T]) Left() *node[T] {
+	return n.left
+}
+
+func (n *node[T]) SetLeft(left *node[T]) {
+	n.left = left
+}
+
+func (n *node[T]) Right() *node[T] {
+	return n.right
+}
+
+func (n *node[T]) SetRight(right *node[T]) {
+	n.right = right
+}
+
+func (n *node[T]) Height() int {
+	return n.height
+}
+
+// Update recalculates the height and max end of n from its children.
+func (n *node[T]) Update() {
+	n.height = avl.Measure(n)
+	n.max = n.hi
+
+	if n.left != nil && n.left.max > n.max {
+		n.max = n.left.max
+	}
+
+	if n.right != nil && n.right.max > n.max {
+		n.max = n.right.max
+	}
+}
@@ -0,0 +1,137 @@
+package intervaltree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_WithIntervals_CountIsCorrect(t *testing.T) {
+	tree := New(
+		Interval[string]{Lo: 1, Hi: 3, Val: "a"},
+		Interval[string]{Lo: 2, Hi: 5, Val: "b"},
+	)
+
+	assert.Equal(t, 2, tree.Count())
+}
+
+func TestInsert_WithEmptyInterval_Panics(t *testing.T) {
+	tree := New[int]()
+
+	assert.Panics(t, func() { tree.Insert(3, 3, 0) })
+}
+
+func TestQueryPoint_WithOverlappingIntervals_ReturnsMatches(t *testing.T) {
+	tree := New(
+		Interval[string]{Lo: 0, Hi: 10, Val: "a"},
+		Interval[string]{Lo: 5, Hi: 6, Val: "b"},
+		Interval[string]{Lo: 6, Hi: 8, Val: "c"},
+		Interval[string]{Lo: 12, Hi: 20, Val: "d"},
+	)
+
+	actual := tree.QueryPoint(6)
+
+	assert.Equal(t, []Interval[string]{
+		{Lo: 0, Hi: 10, Val: "a"},
+		{Lo: 6, Hi: 8, Val: "c"},
+	}, actual)
+}
+
+func TestQueryPoint_WithPointAtEnd_IsExcluded(t *testing.T) {
+	tree := New(Interval[int]{Lo: 1, Hi: 4, Val: 1})
+
+	assert.Empty(t, tree.QueryPoint(4))
+}
+
+func TestQueryRange_WithOverlappingIntervals_ReturnsMatches(t *testing.T) {
+	tree := New(
+		Interval[string]{Lo: 0, Hi: 2, Val: "a"},
+		Interval[string]{Lo: 3, Hi: 7, Val: "b"},
+		Interval[string]{Lo: 8, Hi: 9, Val: "c"},
+		Interval[string]{Lo: 9, Hi: 12, Val: "d"},
+	)
+
+	actual := tree.QueryRange(2, 9)
+
+	assert.Equal(t, []Interval[string]{
+		{Lo: 3, Hi: 7, Val: "b"},
+		{Lo: 8, Hi: 9, Val: "c"},
+	}, actual)
+}
+
+func TestQueryRange_WithEmptyRange_ReturnsNothing(t *testing.T) {
+	tree := New(Interval[int]{Lo: 1, Hi: 4, Val: 1})
+
+	assert.Empty(t, tree.QueryRange(2, 2))
+}
+
+func TestRemove_WithIntervalInTree_IntervalIsRemoved(t *testing.T) {
+	tree := New(
+		Interval[int]{Lo: 1, Hi: 4, Val: 1},
+		Interval[int]{Lo: 2, Hi: 3, Val: 2},
+	)
+
+	e := tree.Remove(1, 4)
+
+	assert.Nil(t, e)
+	assert.Equal(t, 1, tree.Count())
+	assert.Equal(t, []Interval[int]{{Lo: 2, Hi: 3, Val: 2}}, tree.QueryPoint(2))
+}
+
+func TestRemove_WithIntervalNotInTree_ReturnsError(t *testing.T) {
+	tree := New(Interval[int]{Lo: 1, Hi: 4, Val: 1})
+
+	e := tree.Remove(1, 5)
+
+	assert.NotNil(t, e)
+	assert.Equal(t, 1, tree.Count())
+}
+
+func TestClear_WithIntervals_IsEmpty(t *testing.T) {
+	tree := New(Interval[int]{Lo: 1, Hi: 4, Val: 1})
+
+	tree.Clear()
+
+	assert.True(t, tree.IsEmpty())
+	assert.Empty(t, tree.QueryPoint(2))
+}
+
+func TestQueryRange_WithRandomIntervals_MatchesLinearScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := New[int]()
+	intervals := make([]Interval[int], 0)
+
+	for i := 0; i < 500; i++ {
+		lo := r.Intn(1000)
+		in := Interval[int]{Lo: lo, Hi: lo + 1 + r.Intn(50), Val: i}
+
+		tree.Insert(in.Lo, in.Hi, in.Val)
+		intervals = append(intervals, in)
+	}
+
+	for i := 0; i < 200; i++ {
+		in := intervals[i]
+		assert.Nil(t, tree.Remove(in.Lo, in.Hi))
+	}
+
+	remaining := intervals[200:]
+
+	for i := 0; i < 100; i++ {
+		lo := r.Intn(1000)
+		hi := lo + 1 + r.Intn(100)
+
+		expected := 0
+
+		for _, in := range remaining {
+			if in.Lo < hi && lo < in.Hi {
+				expected++
+			}
+		}
+
+		assert.Len(t, tree.QueryRange(lo, hi), expected)
+	}
+
+	assert.Equal(t, len(remaining), tree.Count())
+	assert.LessOrEqual(t, tree.root.height, 12)
+}
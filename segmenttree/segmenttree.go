@@ -0,0 +1,292 @@
+// Package segmenttree provides a segment tree data structure for
+// aggregating ranges of a mutable slice with any associative combine
+// function, such as sum, min, max or gcd.
+// A segment tree provides three main operations:
+//
+//  1. Set which replaces a single value.
+//  2. Query which combines all values in a range.
+//  3. Apply which lazily updates all values in a range, such as adding to or assigning them.
+//
+// Each operation takes O(log n) time.
+//
+// This segment tree data structure is NOT thread-safe.
+package segmenttree
+
+import "github.com/sgago/col"
+
+// A segment tree data structure with type T values and type U range updates.
+type segtree[T any, U any] struct {
+	n        int
+	tree     []T
+	lazy     []U
+	pending  []bool
+	identity T
+	combine  func(a T, b T) T
+	apply    func(agg T, u U, length int) T
+	compose  func(older U, newer U) U
+}
+
+// An Update either adds Value to or assigns Value to every value in a range.
+type Update[T col.Number] struct {
+	Value  T
+	Assign bool
+}
+
+// Add returns an update that adds value to every value in a range.
+func Add[T col.Number](value T) Update[T] {
+	return Update[T]{Value: value}
+}
+
+// Assign returns an update that assigns value to every value in a range.
+func Assign[T col.Number](value T) Update[T] {
+	return Update[T]{Value: value, Assign: true}
+}
+
+// ComposeUpdates returns the single update equivalent to applying older and then newer.
+func ComposeUpdates[T col.Number](older Update[T], newer Update[T]) Update[T] {
+	if newer.Assign {
+		return newer
+	}
+
+	return Update[T]{Value: older.Value + newer.Value, Assign: older.Assign}
+}
+
+// ApplySum returns the sum of length values after applying u to each of them.
+func ApplySum[T col.Number](sum T, u Update[T], length int) T {
+	if u.Assign {
+		return u.Value * T(length)
+	}
+
+	return sum + u.Value*T(length)
+}
+
+// ApplyExtremum returns the minimum or maximum of length values
+// after applying u to each of them.
+func ApplyExtremum[T col.Number](extremum T, u Update[T], _ int) T {
+	if u.Assign {
+		return u.Value
+	}
+
+	return extremum + u.Value
+}
+
+// New allocates and initializes a new segment tree over a copy of values,
+// aggregated by combine, without range updates.
+//
+// The combine function must be associative, and combining any value
+// with identity must return that value.
+func New[T any](values []T, combine func(a T, b T) T, identity T) *segtree[T, struct{}] {
+	return NewLazy[T, struct{}](values, combine, identity, nil, nil)
+}
+
+// NewLazy allocates and initializes a new segment tree over a copy of values,
+// aggregated by combine, with lazy type U range updates.
+//
+// The combine function must be associative, and combining any value
+// with identity must return that value.
+// The apply function returns the aggregate of length values after
+// applying an update to each of them. The compose function returns the
+// single update equivalent to applying an older and then a newer update.
+// See ApplySum, ApplyExtremum and ComposeUpdates for adding and assigning.
+func NewLazy[T any, U any](
+	values []T,
+	combine func(a T, b T) T,
+	identity T,
+	apply func(agg T, u U, length int) T,
+	compose func(older U, newer U) U,
+) *segtree[T, U] {
+	if combine == nil {
+		panic("The combine function cannot be nil.")
+	}
+
+	if (apply == nil) != (compose == nil) {
+		panic("The apply and compose functions must both be nil or non-nil.")
+	}
+
+	s := segtree[T, U]{
+		n:        len(values),
+		tree:     make([]T, 4*len(values)),
+		identity: identity,
+		combine:  combine,
+		apply:    apply,
+		compose:  compose,
+	}
+
+	if apply != nil {
+		s.lazy = make([]U, 4*len(values))
+		s.pending = make([]bool, 4*len(values))
+	}
+
+	if s.n > 0 {
+		s.build(values, 1, 0, s.n)
+	}
+
+	return &s
+}
+
+// Set replaces the value at index.
+//
+// This method panics if index is out of bounds.
+func (s *segtree[T, U]) Set(index int, value T) {
+	if index < 0 || index >= s.n {
+		panic("The index is out of bounds.")
+	}
+
+	s.set(1, 0, s.n, index, value)
+}
+
+// Get returns the value at index.
+//
+// This method panics if index is out of bounds.
+func (s *segtree[T, U]) Get(index int) T {
+	if index < 0 || index >= s.n {
+		panic("The index is out of bounds.")
+	}
+
+	return s.query(1, 0, s.n, index, index+1)
+}
+
+// Query returns all values in [l, r) combined.
+// An empty range returns the identity.
+//
+// This method panics if the range is out of bounds.
+func (s *segtree[T, U]) Query(l int, r int) T {
+	s.checkRange(l, r)
+
+	if l == r {
+		return s.identity
+	}
+
+	return s.query(1, 0, s.n, l, r)
+}
+
+// Apply lazily applies u to every value in [l, r).
+//
+// This method panics if the range is out of bounds or
+// if the tree was created without range updates.
+func (s *segtree[T, U]) Apply(l int, r int, u U) {
+	if s.apply == nil {
+		panic("The segment tree has no range updates.")
+	}
+
+	s.checkRange(l, r)
+
+	if l < r {
+		s.update(1, 0, s.n, l, r, u)
+	}
+}
+
+// Count returns the number of values in the tree.
+func (s *segtree[T, U]) Count() int {
+	return s.n
+}
+
+func (s *segtree[T, U]) checkRange(l int, r int) {
+	if l < 0 || r > s.n || l > r {
+		panic("The range is out of bounds.")
+	}
+}
+
+func (s *segtree[T, U]) build(values []T, node int, l int, r int) {
+	if r-l == 1 {
+		s.tree[node] = values[l]
+		return
+	}
+
+	m := (l + r) / 2
+
+	s.build(values, 2*node, l, m)
+	s.build(values, 2*node+1, m, r)
+
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+func (s *segtree[T, U]) set(node int, l int, r int, index int, value T) {
+	if r-l == 1 {
+		s.tree[node] = value
+		return
+	}
+
+	s.push(node, l, r)
+
+	if m := (l + r) / 2; index < m {
+		s.set(2*node, l, m, index, value)
+	} else {
+		s.set(2*node+1, m, r, index, value)
+	}
+
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+// query combines the values in [ql, qr), which must overlap [l, r).
+func (s *segtree[T, U]) query(node int, l int, r int, ql int, qr int) T {
+	if ql <= l && r <= qr {
+		return s.tree[node]
+	}
+
+	s.push(node, l, r)
+
+	m := (l + r) / 2
+
+	if qr <= m {
+		return s.query(2*node, l, m, ql, qr)
+	}
+
+	if ql >= m {
+		return s.query(2*node+1, m, r, ql, qr)
+	}
+
+	return s.combine(s.query(2*node, l, m, ql, qr), s.query(2*node+1, m, r, ql, qr))
+}
+
+func (s *segtree[T, U]) update(node int, l int, r int, ql int, qr int, u U) {
+	if qr <= l || r <= ql {
+		return
+	}
+
+	if ql <= l && r <= qr {
+		s.applyNode(node, l, r, u)
+		return
+	}
+
+	s.push(node, l, r)
+
+	m := (l + r) / 2
+
+	s.update(2*node, l, m, ql, qr, u)
+	s.update(2*node+1, m, r, ql, qr, u)
+
+	s.tree[node] = s.combine(s.tree[2*node], s.tree[2*node+1])
+}
+
+// applyNode applies u to the node's aggregate and, for internal nodes,
+// records it as pending for the node's children.
+func (s *segtree[T, U]) applyNode(node int, l int, r int, u U) {
+	s.tree[node] = s.apply(s.tree[node], u, r-l)
+
+	if r-l > 1 {
+		if s.pending[node] {
+			s.lazy[node] = s.compose(s.lazy[node], u)
+		} else {
+			s.lazy[node] = u
+			s.pending[node] = true
+		}
+	}
+}
+
+// push moves the node's pending update down to its children.
+func (s *segtree[T, U]) push(node int, l int, r int) {
+	if s.pending == nil || !s.pending[node] {
+		return
+	}
+
+	m := (l + r) / 2
+
+	s.applyNode(2*node, l, m, s.lazy[node])
+	s.applyNode(2*node+1, m, r, s.lazy[node])
+
+	var none U
+
+	s.lazy[node] = none
+	s.pending[node] = false
+}
@@ -0,0 +1,160 @@
+package segmenttree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sum(a int, b int) int {
+	return a + b
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func gcd(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+func TestNew_WithValues_CountIsCorrect(t *testing.T) {
+	s := New([]int{1, 2, 3}, sum, 0)
+
+	assert.Equal(t, 3, s.Count())
+}
+
+func TestQuery_WithSum_IsCorrect(t *testing.T) {
+	s := New([]int{1, 2, 3, 4, 5}, sum, 0)
+
+	assert.Equal(t, 9, s.Query(1, 4))
+	assert.Equal(t, 15, s.Query(0, 5))
+}
+
+func TestQuery_WithEmptyRange_ReturnsIdentity(t *testing.T) {
+	s := New([]int{1, 2, 3}, sum, 0)
+
+	assert.Equal(t, 0, s.Query(2, 2))
+}
+
+func TestQuery_WithOutOfBoundsRange_Panics(t *testing.T) {
+	s := New([]int{1, 2, 3}, sum, 0)
+
+	assert.Panics(t, func() { s.Query(0, 4) })
+	assert.Panics(t, func() { s.Query(2, 1) })
+}
+
+func TestQuery_WithGcd_IsCorrect(t *testing.T) {
+	s := New([]int{12, 18, 24, 7}, gcd, 0)
+
+	assert.Equal(t, 6, s.Query(0, 3))
+	assert.Equal(t, 1, s.Query(0, 4))
+}
+
+func TestSet_WithIndex_UpdatesQueries(t *testing.T) {
+	s := New([]int{5, 3, 8}, min, math.MaxInt)
+
+	s.Set(2, 1)
+
+	assert.Equal(t, 1, s.Query(0, 3))
+	assert.Equal(t, 1, s.Get(2))
+}
+
+func TestSet_WithOutOfBoundsIndex_Panics(t *testing.T) {
+	s := New([]int{1}, sum, 0)
+
+	assert.Panics(t, func() { s.Set(1, 1) })
+}
+
+func TestApply_WithoutRangeUpdates_Panics(t *testing.T) {
+	s := New([]int{1, 2, 3}, sum, 0)
+
+	assert.Panics(t, func() { s.Apply(0, 1, struct{}{}) })
+}
+
+func TestNewLazy_WithOnlyApply_Panics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewLazy[int, Update[int]]([]int{1}, sum, 0, ApplySum[int], nil)
+	})
+}
+
+func TestApply_WithSumAdd_IsCorrect(t *testing.T) {
+	s := NewLazy([]int{1, 2, 3, 4}, sum, 0, ApplySum[int], ComposeUpdates[int])
+
+	s.Apply(1, 3, Add(10))
+
+	assert.Equal(t, 30, s.Query(0, 4))
+	assert.Equal(t, 12, s.Get(1))
+}
+
+func TestApply_WithSumAssignThenAdd_IsCorrect(t *testing.T) {
+	s := NewLazy([]int{1, 2, 3, 4}, sum, 0, ApplySum[int], ComposeUpdates[int])
+
+	s.Apply(0, 4, Assign(5))
+	s.Apply(0, 2, Add(1))
+
+	assert.Equal(t, 22, s.Query(0, 4))
+	assert.Equal(t, 6, s.Get(0))
+	assert.Equal(t, 5, s.Get(3))
+}
+
+func TestApply_WithRandomUpdates_MatchesSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]int, 100)
+
+	for i := range values {
+		values[i] = r.Intn(100)
+	}
+
+	sums := NewLazy(values, sum, 0, ApplySum[int], ComposeUpdates[int])
+	mins := NewLazy(values, min, math.MaxInt, ApplyExtremum[int], ComposeUpdates[int])
+
+	for i := 0; i < 1000; i++ {
+		l := r.Intn(len(values))
+		rr := l + 1 + r.Intn(len(values)-l)
+
+		switch r.Intn(4) {
+		case 0:
+			u := Assign(r.Intn(100))
+			sums.Apply(l, rr, u)
+			mins.Apply(l, rr, u)
+
+			for j := l; j < rr; j++ {
+				values[j] = u.Value
+			}
+		case 1:
+			u := Add(r.Intn(21) - 10)
+			sums.Apply(l, rr, u)
+			mins.Apply(l, rr, u)
+
+			for j := l; j < rr; j++ {
+				values[j] += u.Value
+			}
+		case 2:
+			v := r.Intn(100)
+			sums.Set(l, v)
+			mins.Set(l, v)
+			values[l] = v
+		default:
+			expectedSum, expectedMin := 0, math.MaxInt
+
+			for j := l; j < rr; j++ {
+				expectedSum += values[j]
+				expectedMin = min(expectedMin, values[j])
+			}
+
+			assert.Equal(t, expectedSum, sums.Query(l, rr))
+			assert.Equal(t, expectedMin, mins.Query(l, rr))
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Package splay provides a splay tree data structure, a self-adjusting
+// binary search tree that moves each accessed element to the root.
+// Recently accessed priorities are therefore fast to access again,
+// and every operation takes amortized O(log n) time.
+// A splay tree provides the same operations as a binary search tree:
+//
+//  1. Insert which adds an element to the tree.
+//  2. Find which returns the element with a given priority.
+//  3. Remove which removes the element with a given priority.
+//
+// Because Find, Min and Max restructure the tree, they modify it.
+//
+// This splay tree data structure is NOT thread-safe.
+package splay
+
+import (
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+)
+
+// A splay tree data structure with type T values.
+type Tree[T any] struct {
+	root  *node[T]
+	count int
+}
+
+type node[T any] struct {
+	col.PV[T]
+	left  *node[T]
+	right *node[T]
+}
+
+// New allocates and initializes a new splay tree with type T values.
+func New[T any](pvs ...col.PV[T]) *Tree[T] {
+	t := Tree[T]{}
+
+	for _, pv := range pvs {
+		t.Insert(pv)
+	}
+
+	return &t
+}
+
+// Insert adds an element to the tree and moves it to the root.
+func (t *Tree[T]) Insert(pv col.PV[T]) {
+	n := &node[T]{PV: pv}
+
+	if t.root != nil {
+		root := splay(t.root, towards(pv.Priority))
+
+		if pv.Priority < root.Priority {
+			n.left, n.right = root.left, root
+			root.left = nil
+		} else {
+			n.left, n.right = root, root.right
+			root.right = nil
+		}
+	}
+
+	t.root = n
+	t.count++
+}
+
+// Find returns the element with the given priority and moves it to the root.
+// If no such element exists, Find returns an error.
+func (t *Tree[T]) Find(priority int) (col.PV[T], error) {
+	if t.root != nil {
+		t.root = splay(t.root, towards(priority))
+
+		if t.root.Priority == priority {
+			return t.root.PV, nil
+		}
+	}
+
+	return col.PV[T]{}, &err.KeyNotFound{Key: priority}
+}
+
+// Remove removes an element with the given priority from the tree.
+// If no such element exists, Remove returns an error.
+func (t *Tree[T]) Remove(priority int) error {
+	if t.root == nil {
+		return &err.KeyNotFound{Key: priority}
+	}
+
+	t.root = splay(t.root, towards(priority))
+
+	if t.root.Priority != priority {
+		return &err.KeyNotFound{Key: priority}
+	}
+
+	if t.root.left == nil {
+		t.root = t.root.right
+	} else {
+		// The largest element on the left has no right child once splayed.
+		right := t.root.right
+		t.root = splay(t.root.left, rightmost)
+		t.root.right = right
+	}
+
+	t.count--
+
+	return nil
+}
+
+// Min returns the element with the smallest priority in the tree
+// and moves it to the root.
+//
+// This method panics if the tree is empty.
+func (t *Tree[T]) Min() col.PV[T] {
+	if t.root == nil {
+		panic("The tree is empty.")
+	}
+
+	t.root = splay(t.root, leftmost)
+
+	return t.root.PV
+}
+
+// Max returns the element with the largest priority in the tree
+// and moves it to the root.
+//
+// This method panics if the tree is empty.
+func (t *Tree[T]) Max() col.PV[T] {
+	if t.root == nil {
+		panic("The tree is empty.")
+	}
+
+	t.root = splay(t.root, rightmost)
+
+	return t.root.PV
+}
+
+// PreOrder calls visit for each element, visiting each element before its subtrees.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) PreOrder(visit func(pv col.PV[T]) bool) {
+	t.root.preOrder(visit)
+}
+
+// InOrder calls visit for each element in ascending priority order.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) InOrder(visit func(pv col.PV[T]) bool) {
+	t.root.inOrder(visit)
+}
+
+// PostOrder calls visit for each element, visiting each element after its subtrees.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) PostOrder(visit func(pv col.PV[T]) bool) {
+	t.root.postOrder(visit)
+}
+
+// Count returns the number of elements in the tree.
+func (t *Tree[T]) Count() int {
+	return t.count
+}
+
+// Height returns the number of levels in the tree.
+// An empty tree has a height of zero.
+func (t *Tree[T]) Height() int {
+	return t.root.height()
+}
+
+// IsEmpty returns true if the tree has no elements;
+// otherwise, false.
+func (t *Tree[T]) IsEmpty() bool {
+	return t.Count() == 0
+}
+
+// Clear removes all elements from the tree.
+func (t *Tree[T]) Clear() {
+	t.root = nil
+	t.count = 0
+}
+
+// towards returns a direction function that searches for priority.
+func towards(priority int) func(p int) int {
+	return func(p int) int {
+		switch {
+		case priority < p:
+			return -1
+		case priority > p:
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+func leftmost(int) int {
+	return -1
+}
+
+func rightmost(int) int {
+	return 1
+}
+
+// splay moves the last node on the path given by dir to the root of
+// the subtree rooted at n and returns it. The dir function returns a
+// negative number to go left, a positive number to go right, and zero to stop.
+func splay[T any](n *node[T], dir func(p int) int) *node[T] {
+	switch dir(n.Priority) {
+	case -1:
+		if n.left == nil {
+			return n
+		}
+
+		switch dir(n.left.Priority) {
+		case -1:
+			if n.left.left != nil {
+				n.left.left = splay(n.left.left, dir)
+				n = rotateRight(n)
+			}
+		case 1:
+			if n.left.right != nil {
+				n.left.right = splay(n.left.right, dir)
+				n.left = rotateLeft(n.left)
+			}
+		}
+
+		return rotateRight(n)
+	case 1:
+		if n.right == nil {
+			return n
+		}
+
+		switch dir(n.right.Priority) {
+		case 1:
+			if n.right.right != nil {
+				n.right.right = splay(n.right.right, dir)
+				n = rotateLeft(n)
+			}
+		case -1:
+			if n.right.left != nil {
+				n.right.left = splay(n.right.left, dir)
+				n.right = rotateRight(n.right)
+			}
+		}
+
+		return rotateLeft(n)
+	}
+
+	return n
+}
+
+func rotateLeft[T any](n *node[T]) *node[T] {
+	r := n.right
+
+	n.right = r.left
+	r.left = n
+
+	return r
+}
+
+func rotateRight[T any](n *node[T]) *node[T] {
+	l := n.left
+
+	n.left = l.right
+	l.right = n
+
+	return l
+}
+
+func (n *node[T]) height() int {
+	if n == nil {
+		return 0
+	}
+
+	left, right := n.left.height(), n.right.height()
+
+	if left > right {
+		return left + 1
+	}
+
+	return right + 1
+}
+
+func (n *node[T]) preOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (visit(n.PV) && n.left.preOrder(visit) && n.right.preOrder(visit))
+}
+
+func (n *node[T]) inOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (n.left.inOrder(visit) && visit(n.PV) && n.right.inOrder(visit))
+}
+
+func (n *node[T]) postOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (n.left.postOrder(visit) && n.right.postOrder(visit) && visit(n.PV))
+}
@@ -0,0 +1,166 @@
+package splay
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/sgago/col"
+	"github.com/stretchr/testify/assert"
+)
+
+var pv1 col.PV[int] = col.PV[int]{Priority: 1, Val: 1}
+var pv2 col.PV[int] = col.PV[int]{Priority: 2, Val: 2}
+var pv3 col.PV[int] = col.PV[int]{Priority: 3, Val: 3}
+var pv4 col.PV[int] = col.PV[int]{Priority: 4, Val: 4}
+
+func priorities(t *Tree[int]) []int {
+	result := make([]int, 0)
+
+	t.InOrder(func(pv col.PV[int]) bool {
+		result = append(result, pv.Priority)
+		return true
+	})
+
+	return result
+}
+
+func TestNew_WithNoValues_IsEmpty(t *testing.T) {
+	tr := New[int]()
+
+	assert.True(t, tr.IsEmpty())
+	assert.Zero(t, tr.Height())
+}
+
+func TestInsert_WithValues_InOrderIsSorted(t *testing.T) {
+	tr := New(pv3, pv1, pv4, pv2)
+
+	assert.Equal(t, []int{1, 2, 3, 4}, priorities(tr))
+	assert.Equal(t, 4, tr.Count())
+}
+
+func TestFind_WithPriorityInTree_IsFound(t *testing.T) {
+	tr := New(pv3, pv1, pv4, pv2)
+
+	pv, e := tr.Find(4)
+
+	assert.Nil(t, e)
+	assert.Equal(t, pv4, pv)
+}
+
+func TestFind_WithPriorityNotInTree_ReturnsError(t *testing.T) {
+	tr := New(pv3, pv1)
+
+	_, e := tr.Find(7)
+
+	assert.NotNil(t, e)
+}
+
+func TestRemove_WithPriorityInTree_IsRemoved(t *testing.T) {
+	tr := New(pv3, pv1, pv4, pv2)
+
+	e := tr.Remove(3)
+
+	assert.Nil(t, e)
+	assert.Equal(t, []int{1, 2, 4}, priorities(tr))
+	assert.Equal(t, 3, tr.Count())
+}
+
+func TestRemove_WithPriorityNotInTree_ReturnsError(t *testing.T) {
+	tr := New(pv3, pv1)
+
+	assert.NotNil(t, tr.Remove(7))
+	assert.Equal(t, 2, tr.Count())
+}
+
+func TestFind_WithPriorityInTree_MovesToRoot(t *testing.T) {
+	tr := New(pv3, pv1, pv4, pv2)
+
+	tr.Find(1)
+
+	assert.Equal(t, 1, tr.root.Priority)
+}
+
+func TestInsert_WithValue_MovesToRoot(t *testing.T) {
+	tr := New(pv3, pv1, pv4)
+
+	tr.Insert(pv2)
+
+	assert.Equal(t, 2, tr.root.Priority)
+}
+
+func TestMinMax_WithElements_AreCorrect(t *testing.T) {
+	tr := New(pv3, pv1, pv4, pv2)
+
+	assert.Equal(t, pv1, tr.Min())
+	assert.Equal(t, pv4, tr.Max())
+}
+
+func TestMin_WithEmptyTree_Panics(t *testing.T) {
+	tr := New[int]()
+
+	assert.Panics(t, func() { tr.Min() })
+}
+
+func TestPreOrder_WithElements_VisitsRootFirst(t *testing.T) {
+	tr := New(pv3, pv1, pv4, pv2)
+
+	first := 0
+
+	tr.PreOrder(func(pv col.PV[int]) bool {
+		first = pv.Priority
+		return false
+	})
+
+	assert.Equal(t, tr.root.Priority, first)
+}
+
+func TestPostOrder_WithElements_VisitsRootLast(t *testing.T) {
+	tr := New(pv3, pv1, pv4, pv2)
+
+	last := 0
+
+	tr.PostOrder(func(pv col.PV[int]) bool {
+		last = pv.Priority
+		return true
+	})
+
+	assert.Equal(t, tr.root.Priority, last)
+}
+
+func TestFind_WithSortedAccesses_Rebalances(t *testing.T) {
+	tr := New[int]()
+
+	for i := 0; i < 1024; i++ {
+		tr.Insert(col.PV[int]{Priority: i, Val: i})
+	}
+
+	assert.Equal(t, 1024, tr.Height())
+
+	tr.Find(0)
+
+	assert.Less(t, tr.Height(), 520)
+}
+
+func TestInsertAndRemove_WithRandomValues_StaysSorted(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := New[int]()
+	expected := make([]int, 0)
+
+	for i := 0; i < 1000; i++ {
+		p := r.Intn(100)
+		tr.Insert(col.PV[int]{Priority: p, Val: i})
+		expected = append(expected, p)
+	}
+
+	for i := 0; i < 500; i++ {
+		p := expected[i]
+		assert.Nil(t, tr.Remove(p))
+	}
+
+	remaining := append([]int{}, expected[500:]...)
+	sort.Ints(remaining)
+
+	assert.Equal(t, remaining, priorities(tr))
+	assert.Equal(t, 500, tr.Count())
+}
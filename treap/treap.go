@@ -0,0 +1,298 @@
+// Package treap provides a treap data structure, a binary search tree
+// that stays balanced in expectation by also keeping each node's random
+// weight in heap order.
+// A treap provides the same operations as a binary search tree:
+//
+//  1. Insert which adds an element to the tree.
+//  2. Find which returns the element with a given priority.
+//  3. Remove which removes the element with a given priority.
+//
+// It also provides Split and Join, which divide a treap by priority and
+// concatenate two treaps, respectively, in O(log n) expected time.
+//
+// This treap data structure is NOT thread-safe.
+package treap
+
+import (
+	"math/rand"
+
+	"github.com/sgago/col"
+	"github.com/sgago/col/err"
+)
+
+// A treap data structure with type T values.
+type Tree[T any] struct {
+	root *node[T]
+}
+
+// Nodes are kept in max heap order by weight, and track their subtree's size.
+type node[T any] struct {
+	col.PV[T]
+	weight uint32
+	size   int
+	left   *node[T]
+	right  *node[T]
+}
+
+// New allocates and initializes a new treap with type T values.
+func New[T any](pvs ...col.PV[T]) *Tree[T] {
+	t := Tree[T]{}
+
+	for _, pv := range pvs {
+		t.Insert(pv)
+	}
+
+	return &t
+}
+
+// Insert adds an element to the tree.
+func (t *Tree[T]) Insert(pv col.PV[T]) {
+	left, right := split(t.root, pv.Priority)
+
+	n := &node[T]{PV: pv, weight: rand.Uint32(), size: 1}
+
+	t.root = merge(merge(left, n), right)
+}
+
+// Find returns the element with the given priority.
+// If no such element exists, Find returns an error.
+func (t *Tree[T]) Find(priority int) (col.PV[T], error) {
+	n := t.root
+
+	for n != nil && n.Priority != priority {
+		if n.Priority > priority {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	if n == nil {
+		return col.PV[T]{}, &err.KeyNotFound{Key: priority}
+	}
+
+	return n.PV, nil
+}
+
+// Remove removes an element with the given priority from the tree.
+// If no such element exists, Remove returns an error.
+func (t *Tree[T]) Remove(priority int) error {
+	root, removed := remove(t.root, priority)
+
+	if !removed {
+		return &err.KeyNotFound{Key: priority}
+	}
+
+	t.root = root
+
+	return nil
+}
+
+// Split removes all elements from the tree and returns them as two trees.
+// The first tree holds the elements with priorities less than priority,
+// and the second tree holds the rest.
+func (t *Tree[T]) Split(priority int) (*Tree[T], *Tree[T]) {
+	left, right := split(t.root, priority)
+
+	t.root = nil
+
+	return &Tree[T]{root: left}, &Tree[T]{root: right}
+}
+
+// Join moves all elements from other to the end of the tree, leaving other empty.
+//
+// This method panics if any priority in other is less than a priority in the tree.
+func (t *Tree[T]) Join(other *Tree[T]) {
+	if t.root != nil && other.root != nil && t.root.max().Priority > other.root.min().Priority {
+		panic("The trees overlap.")
+	}
+
+	t.root = merge(t.root, other.root)
+	other.root = nil
+}
+
+// Min returns the element with the smallest priority in the tree.
+//
+// This method panics if the tree is empty.
+func (t *Tree[T]) Min() col.PV[T] {
+	if t.root == nil {
+		panic("The tree is empty.")
+	}
+
+	return t.root.min().PV
+}
+
+// Max returns the element with the largest priority in the tree.
+//
+// This method panics if the tree is empty.
+func (t *Tree[T]) Max() col.PV[T] {
+	if t.root == nil {
+		panic("The tree is empty.")
+	}
+
+	return t.root.max().PV
+}
+
+// PreOrder calls visit for each element, visiting each element before its subtrees.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) PreOrder(visit func(pv col.PV[T]) bool) {
+	t.root.preOrder(visit)
+}
+
+// InOrder calls visit for each element in ascending priority order.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) InOrder(visit func(pv col.PV[T]) bool) {
+	t.root.inOrder(visit)
+}
+
+// PostOrder calls visit for each element, visiting each element after its subtrees.
+// Returning false from visit stops the iteration.
+func (t *Tree[T]) PostOrder(visit func(pv col.PV[T]) bool) {
+	t.root.postOrder(visit)
+}
+
+// Count returns the number of elements in the tree.
+func (t *Tree[T]) Count() int {
+	return t.root.getSize()
+}
+
+// Height returns the number of levels in the tree.
+// An empty tree has a height of zero.
+func (t *Tree[T]) Height() int {
+	return t.root.height()
+}
+
+// IsEmpty returns true if the tree has no elements;
+// otherwise, false.
+func (t *Tree[T]) IsEmpty() bool {
+	return t.Count() == 0
+}
+
+// Clear removes all elements from the tree.
+func (t *Tree[T]) Clear() {
+	t.root = nil
+}
+
+// split divides the subtree rooted at n into the nodes with
+// priorities less than priority and the rest.
+func split[T any](n *node[T], priority int) (*node[T], *node[T]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if n.Priority < priority {
+		left, right := split(n.right, priority)
+		n.right = left
+		n.update()
+
+		return n, right
+	}
+
+	left, right := split(n.left, priority)
+	n.left = right
+	n.update()
+
+	return left, n
+}
+
+// merge joins two subtrees where every priority in left
+// is less than or equal to every priority in right.
+func merge[T any](left *node[T], right *node[T]) *node[T] {
+	if left == nil {
+		return right
+	}
+
+	if right == nil {
+		return left
+	}
+
+	if left.weight > right.weight {
+		left.right = merge(left.right, right)
+		left.update()
+
+		return left
+	}
+
+	right.left = merge(left, right.left)
+	right.update()
+
+	return right
+}
+
+func remove[T any](n *node[T], priority int) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	if n.Priority == priority {
+		return merge(n.left, n.right), true
+	}
+
+	var removed bool
+
+	if n.Priority > priority {
+		n.left, removed = remove(n.left, priority)
+	} else {
+		n.right, removed = remove(n.right, priority)
+	}
+
+	if removed {
+		n.update()
+	}
+
+	return n, removed
+}
+
+func (n *node[T]) update() {
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *node[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+func (n *node[T]) min() *node[T] {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n
+}
+
+func (n *node[T]) max() *node[T] {
+	for n.right != nil {
+		n = n.right
+	}
+
+	return n
+}
+
+func (n *node[T]) height() int {
+	if n == nil {
+		return 0
+	}
+
+	left, right := n.left.height(), n.right.height()
+
+	if left > right {
+		return left + 1
+	}
+
+	return right + 1
+}
+
+func (n *node[T]) preOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (visit(n.PV) && n.left.preOrder(visit) && n.right.preOrder(visit))
+}
+
+func (n *node[T]) inOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (n.left.inOrder(visit) && visit(n.PV) && n.right.inOrder(visit))
+}
+
+func (n *node[T]) postOrder(visit func(pv col.PV[T]) bool) bool {
+	return n == nil || (n.left.postOrder(visit) && n.right.postOrder(visit) && visit(n.PV))
+}